@@ -2,18 +2,19 @@ package telegram
 
 import (
 	"bytes"
-	"io"
-	"log"
-	"mime/multipart"
-	"net"
+	"context"
+	stdjson "encoding/json"
+	"io/ioutil"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	json "github.com/json-iterator/go"
 	"github.com/kirillDanshin/dlog"
 	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
 )
 
 // Bot represents a bot user with access token getted from @BotFather.
@@ -22,10 +23,28 @@ type Bot struct {
 	AccessToken string
 	Updates     chan *Update
 
-	client   *http.Client
-	marshler json.API
+	transport Transport
+	marshler  json.API
+	scheme    string
+	host      string
+	prefix    string
+	local     bool
+	retry     *RetryPolicy
+	migrated  MigrationHook
+	limiter   Limiter
+	uploads   UploadCache
+	nonces    NonceStore
 }
 
+// defaultTransport is used by Bot without custom transport.
+var defaultTransport Transport = NewFastHTTPTransport(nil) //nolint: gochecknoglobals
+
+// Default endpoint of the Telegram Bot API.
+const (
+	defaultScheme string = "https"
+	defaultHost   string = "api.telegram.org"
+)
+
 // New creates a new default Bot structure based on the input access token.
 func New(accessToken string) (b *Bot, err error) {
 	b = new(Bot)
@@ -37,102 +56,243 @@ func New(accessToken string) (b *Bot, err error) {
 	return b, err
 }
 
-// SetClient allow set custom fasthttp.Client (for proxy traffic, for example).
+// SetClient allow set custom fasthttp.Client (for proxy traffic, for example). Use SetTransport with
+// NetHTTPTransport for net/http.Client.
 func (b *Bot) SetClient(newClient *http.Client) {
 	if b == nil {
 		b = new(Bot)
 	}
 
-	b.client = newClient
+	b.transport = NewFastHTTPTransport(newClient)
+}
+
+// SetEndpoint allow set custom Bot API server address (self-hosted server or local test stand-in, for example). The
+// pathPrefix is prepended to all API and file paths. Empty scheme and host are reset to the defaults.
+//
+// This must be called before any API request, so call GetMe manually after it if Bot is created without New.
+func (b *Bot) SetEndpoint(scheme, host, pathPrefix string) {
+	if b == nil {
+		b = new(Bot)
+	}
+
+	b.scheme, b.host, b.prefix = scheme, host, pathPrefix
 }
 
-func (b Bot) Do(method string, payload interface{}) ([]byte, error) {
+// SetLocalMode marks the Bot API server as launched in local mode. In this mode GetFile returns absolute paths of
+// files on the server host, so NewFileURL creates file:// URIs for them.
+func (b *Bot) SetLocalMode(local bool) {
+	if b == nil {
+		b = new(Bot)
+	}
+
+	b.local = local
+}
+
+// IsLocalMode checks that the Bot API server is marked as launched in local mode.
+func (b Bot) IsLocalMode() bool { return b.local }
+
+// newURI creates a new fasthttp.URI to the current endpoint with provided path elements.
+func (b Bot) newURI(elem ...string) *http.URI {
 	u := http.AcquireURI()
-	defer http.ReleaseURI(u)
-	u.SetScheme("https")
-	u.SetHost("api.telegram.org")
-	u.SetPath(path.Join("bot"+b.AccessToken, method))
+	u.SetScheme(defaultScheme)
+	u.SetHost(defaultHost)
 
-	var buf bytes.Buffer
-	if err := b.marshler.NewEncoder(&buf).Encode(payload); err != nil {
-		return nil, err
+	if b.scheme != "" {
+		u.SetScheme(b.scheme)
 	}
 
-	req := http.AcquireRequest()
-	defer http.ReleaseRequest(req)
-	req.Header.SetUserAgent("toby3d/telegram")
-	req.Header.SetMethod(http.MethodPost)
-	req.Header.SetContentType("application/json")
-	req.SetHostBytes(u.Host())
-	req.SetRequestURI(u.String())
-	req.SetBody(buf.Bytes())
+	if b.host != "" {
+		u.SetHost(b.host)
+	}
+
+	u.SetPath(path.Join(append([]string{"/", b.prefix}, elem...)...))
 
-	resp := http.AcquireResponse()
-	defer http.ReleaseResponse(resp)
+	return u
+}
 
-	if err := b.client.Do(req, resp); err != nil {
+// Do sends JSON-encoded payload to the method and returns a successful Response. Unsuccessful responses are returned
+// as *Error.
+func (b Bot) Do(method string, payload interface{}) (*Response, error) {
+	return b.DoContext(context.Background(), method, payload)
+}
+
+// DoContext is the same as Do, but with a context.Context. Cancellation of ctx returns ctx.Err() without waiting
+// for the response, but the request may be still sent and take effect unless transport aborts it (see
+// FastHTTPTransport).
+func (b Bot) DoContext(ctx context.Context, method string, payload interface{}) (*Response, error) {
+	src, err := b.marshler.Marshal(payload)
+	if err != nil {
 		return nil, err
 	}
 
-	return resp.Body(), nil
-}
+	build := func(req *TransportRequest) error {
+		req.ContentType = "application/json"
+		req.Body = bytes.NewReader(src)
+		req.ContentLength = int64(len(src))
 
-func (b Bot) Upload(method string, payload map[string]string, files ...*InputFile) ([]byte, error) {
-	if len(files) == 0 {
-		return b.Do(method, payload)
+		return nil
 	}
 
-	body := new(bytes.Buffer)
-	w := multipart.NewWriter(body)
+	chatID := b.marshler.Get(src, "chat_id").ToString()
+	resp, err := b.send(ctx, method, chatID, build)
 
-	for i := range files {
-		_, fileName := filepath.Split(files[i].Attachment.Name())
+	migrated, repeat := b.migrate(ctx, err, chatID, true)
 
-		part, err := w.CreateFormFile(fileName, fileName)
+	switch {
+	case migrated == nil:
+		return resp, err
+	case !repeat:
+		return nil, migrated
+	}
+
+	if src, err = replaceChatID(src, migrated.NewChatID); err != nil {
+		return nil, err
+	}
+
+	return b.send(ctx, method, strconv.FormatInt(migrated.NewChatID, 10), build)
+}
+
+// Upload sends payload with files of parts to the method as multipart/form-data and returns a successful Response.
+// Unsuccessful responses are returned as *Error.
+func (b Bot) Upload(method string, payload map[string]string, parts ...UploadPart) (*Response, error) {
+	return b.UploadContext(context.Background(), method, payload, parts...)
+}
+
+// UploadContext is the same as Upload, but with a context.Context. Cancellation of ctx returns ctx.Err() without
+// waiting for the response, but the request may be still sent and take effect unless transport aborts it (see
+// FastHTTPTransport).
+//
+// The form is streamed without buffering files in memory, so the request can be repeated (by retry policy or after
+// chat migration) only if all files are io.Seeker. Use WithUploadProgress for track sending of the body.
+func (b Bot) UploadContext(ctx context.Context, method string, payload map[string]string, parts ...UploadPart) (
+	*Response, error) {
+	if len(parts) == 0 {
+		// Maps are encoded by stdlib, because json-iterator crashes on them with recent Go versions.
+		src, err := stdjson.Marshal(payload)
 		if err != nil {
 			return nil, err
 		}
 
-		if _, err = io.Copy(part, files[i].Attachment); err != nil {
-			return nil, err
-		}
+		return b.DoContext(ctx, method, stdjson.RawMessage(src))
+	}
+
+	u := newUpload(ctx, payload, parts)
+	defer u.close()
+
+	chatID := payload["chat_id"]
+	resp, err := b.send(ctx, method, chatID, u.build)
+
+	migrated, repeat := b.migrate(ctx, err, chatID, u.repeatable())
+
+	switch {
+	case migrated == nil:
+		return resp, err
+	case !repeat:
+		return nil, migrated
 	}
 
+	u.payload = make(map[string]string, len(payload))
 	for key, val := range payload {
-		if err := w.WriteField(key, val); err != nil {
+		u.payload[key] = val
+	}
+
+	u.payload["chat_id"] = strconv.FormatInt(migrated.NewChatID, 10)
+
+	return b.send(ctx, method, u.payload["chat_id"], u.build)
+}
+
+// send executes request to the method for the chatID and decodes the response. The build function fills the body of
+// the request and is called before each attempt, so request can be repeated according to the retry policy. Each
+// attempt waits for the permission of limiter, if any.
+func (b Bot) send(ctx context.Context, method, chatID string, build func(*TransportRequest) error) (*Response,
+	error) {
+	u := b.newURI("bot"+b.AccessToken, method)
+	uri := u.String()
+	http.ReleaseURI(u)
+
+	var (
+		waited  time.Duration
+		lastErr error
+	)
+
+	for attempt := 1; ; attempt++ {
+		if b.limiter != nil {
+			if err := b.limiter.Wait(ctx, method, chatID); err != nil {
+				return nil, err
+			}
+		}
+
+		req := &TransportRequest{Method: http.MethodPost, URI: uri, ContentLength: -1}
+		if err := build(req); err != nil {
+			// Request which cannot be built again is not repeated, so the last error is returned.
+			if lastErr != nil {
+				return nil, lastErr
+			}
+
+			return nil, err
+		}
+
+		resp, err := b.sendOnce(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		lastErr = err
+
+		delay, ok := b.retry.delay(attempt, waited, err)
+		if !ok {
 			return nil, err
 		}
+
+		if !sleep(ctx, delay) {
+			return nil, ctx.Err()
+		}
+
+		waited += delay
 	}
+}
 
-	if err := w.Close(); err != nil {
+// sendOnce executes prepared request by the transport and decodes the response.
+func (b Bot) sendOnce(ctx context.Context, req *TransportRequest) (*Response, error) {
+	t := b.transport
+	if t == nil {
+		t = defaultTransport
+	}
+
+	resp, err := t.Do(ctx, req)
+	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	u := http.AcquireURI()
-	defer http.ReleaseURI(u)
-	u.SetScheme("https")
-	u.SetHost("api.telegram.org")
-	u.SetPath(path.Join("bot"+b.AccessToken, method))
-
-	req := http.AcquireRequest()
-	defer http.ReleaseRequest(req)
-	req.Header.SetMethod(http.MethodPost)
-	req.SetRequestURIBytes(u.RequestURI())
-	req.Header.SetContentType(w.FormDataContentType())
-	req.Header.SetMultipartFormBoundary(w.Boundary())
-
-	if _, err := body.WriteTo(req.BodyWriter()); err != nil {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
 		return nil, err
 	}
 
-	resp := http.AcquireResponse()
-	defer http.ReleaseResponse(resp)
+	return b.decode(resp.StatusCode, body)
+}
+
+// decode parses the body of the API response and checks its status.
+func (b Bot) decode(status int, body []byte) (*Response, error) {
+	result := new(Response)
+	if err := b.marshler.Unmarshal(body, result); err != nil {
+		if status != http.StatusOK {
+			return nil, &Error{
+				Code:        status,
+				Description: http.StatusMessage(status),
+				frame:       xerrors.Caller(1),
+			}
+		}
 
-	if err := b.client.Do(req, resp); err != nil {
 		return nil, err
 	}
 
-	return resp.Body(), nil
+	if !result.Ok {
+		return nil, newError(result)
+	}
+
+	return result, nil
 }
 
 // IsMessageFromMe checks that the input message is a message from the current bot.
@@ -211,17 +371,22 @@ func (b Bot) IsMessageToMe(m Message) bool {
 }
 
 // NewFileURL creates a fasthttp.URI to file with path getted from GetFile method.
+//
+// In local mode absolute file paths are returned as file:// URI.
 func (b Bot) NewFileURL(filePath string) *http.URI {
 	if b.AccessToken == "" || filePath == "" {
 		return nil
 	}
 
-	result := http.AcquireURI()
-	result.SetScheme("https")
-	result.SetHost("api.telegram.org")
-	result.SetPath(path.Join("file", "bot"+b.AccessToken, filePath))
+	if b.local && filepath.IsAbs(filePath) {
+		result := http.AcquireURI()
+		result.SetScheme(SchemeFile)
+		result.SetPath(filePath)
 
-	return result
+		return result
+	}
+
+	return b.newURI("file", "bot"+b.AccessToken, filePath)
 }
 
 // NewRedirectURL creates new fasthttp.URI for redirecting from one chat to another.
@@ -249,91 +414,13 @@ func (b Bot) NewRedirectURL(param string, group bool) *http.URI {
 }
 
 // NewLongPollingChannel creates channel for receive incoming updates using long polling.
+//
+// Deprecated: use NewPoller which can be stopped and reports errors.
 func (b *Bot) NewLongPollingChannel(params *GetUpdates) chan *Update {
-	if params == nil {
-		params = &GetUpdates{
-			Offset:  0,
-			Limit:   100,
-			Timeout: 60,
-		}
-	}
-
-	b.Updates = make(chan *Update, params.Limit)
-
-	go func() {
-		for {
-			updates, err := b.GetUpdates(params)
-			if err != nil {
-				dlog.Ln(err.Error())
-				dlog.Ln("Failed to get updates, retrying in 3 seconds...")
-				time.Sleep(time.Second * 3)
-				continue
-			}
-
-			for _, update := range updates {
-				if update.UpdateID < params.Offset {
-					continue
-				}
-
-				params.Offset = update.UpdateID + 1
-				b.Updates <- update
-			}
-		}
-	}()
+	p := b.NewPoller(params)
+	p.ErrorHandler = func(err error) { dlog.Ln("Failed to get updates:", err.Error()) }
+	_ = p.Start(context.Background())
+	b.Updates = p.Updates
 
 	return b.Updates
 }
-
-// NewWebhookChannel creates channel for receive incoming updates via an outgoing webhook.
-//
-// If cert argument is provided by two strings (["path/to/cert.file", "path/to/cert.key"]), then TLS server will be created by this filepaths.
-func (b *Bot) NewWebhookChannel(u *http.URI, p SetWebhook, ln net.Listener, crt ...string) (chan *Update, func() error) {
-	b.Updates = make(chan *Update, 100)
-	handleFunc := func(ctx *http.RequestCtx) {
-		dlog.Ln("Request path:", string(ctx.Path()))
-
-		if !bytes.HasPrefix(ctx.Path(), u.Path()) {
-			dlog.Ln("Unsupported request path:", string(ctx.Path()))
-			return
-		}
-
-		dlog.Ln("Catched supported request path:", string(ctx.Path()))
-
-		upd := new(Update)
-		if err := b.marshler.Unmarshal(ctx.Request.Body(), upd); err != nil {
-			return
-		}
-
-		b.Updates <- upd
-	}
-
-	srv := http.Server{
-		Name:              b.Username,
-		Concurrency:       p.MaxConnections,
-		Handler:           handleFunc,
-		ReduceMemoryUsage: true,
-	}
-
-	var err error
-
-	go func() {
-		switch {
-		case len(crt) == 2:
-			dlog.Ln("Creating TLS router...")
-			err = srv.ServeTLS(ln, crt[0], crt[1])
-		default:
-			dlog.Ln("Creating simple router...")
-			err = srv.Serve(ln)
-		}
-
-		if err != nil {
-			log.Fatalln(err.Error())
-		}
-	}()
-
-	if _, err = b.SetWebhook(p); err != nil {
-		log.Fatalln(err.Error())
-	}
-
-	return b.Updates, srv.Shutdown
-}
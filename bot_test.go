@@ -0,0 +1,87 @@
+package telegram
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+// newTestBot creates a new Bot which sends all requests to the in-memory server with provided handler. Returned
+// function stops the server.
+func newTestBot(handler http.RequestHandler) (*Bot, func()) {
+	ln := fasthttputil.NewInmemoryListener()
+	srv := &http.Server{Handler: handler}
+
+	go func() { _ = srv.Serve(ln) }()
+
+	b := &Bot{AccessToken: "123:abc", marshler: json.ConfigFastest}
+	b.SetEndpoint("http", "telegram.test", "")
+	b.SetClient(&http.Client{Dial: func(string) (net.Conn, error) { return ln.Dial() }})
+
+	return b, func() { _ = ln.Close() }
+}
+
+func TestBotDoContext(t *testing.T) {
+	t.Run("canceled", func(t *testing.T) {
+		b := Bot{marshler: json.ConfigFastest}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		resp, err := b.DoContext(ctx, MethodGetMe, nil)
+		assert.Nil(t, resp)
+		assert.Equal(t, context.Canceled, err)
+	})
+	t.Run("endpoint", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			assert.Equal(t, "/bot123:abc/getMe", string(ctx.Path()))
+			ctx.SetBodyString(`{"ok":true,"result":{"id":42,"is_bot":true,"first_name":"Test"}}`)
+		})
+		defer stop()
+
+		u, err := b.GetMe()
+		assert.NoError(t, err)
+		assert.Equal(t, 42, u.ID)
+	})
+}
+
+func TestBotNewFileURL(t *testing.T) {
+	b := Bot{AccessToken: "123:abc"}
+	assert.Equal(t, "https://api.telegram.org/file/bot123:abc/photos/file_0.jpg",
+		b.NewFileURL("photos/file_0.jpg").String())
+
+	b.SetEndpoint("http", "localhost:8081", "/tg")
+	assert.Equal(t, "http://localhost:8081/tg/file/bot123:abc/photos/file_0.jpg",
+		b.NewFileURL("photos/file_0.jpg").String())
+
+	b.SetLocalMode(true)
+	assert.Equal(t, "file:///var/lib/telegram-bot-api/photos/file_0.jpg",
+		b.NewFileURL("/var/lib/telegram-bot-api/photos/file_0.jpg").String())
+}
+
+func TestBotUploadContext(t *testing.T) {
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		form, err := ctx.MultipartForm()
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"42"}, form.Value["chat_id"])
+
+			if assert.Len(t, form.File["photo"], 1) {
+				assert.Equal(t, "image.png", form.File["photo"][0].Filename)
+				assert.Equal(t, int64(5), form.File["photo"][0].Size)
+			}
+		}
+
+		ctx.SetBodyString(`{"ok":true,"result":true}`)
+	})
+	defer stop()
+
+	_, err := b.Upload(MethodSendPhoto, map[string]string{"chat_id": "42"},
+		UploadPart{Field: "photo", File: NewInputFileReader("image.png", strings.NewReader("image"))})
+	assert.NoError(t, err)
+}
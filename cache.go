@@ -0,0 +1,279 @@
+package telegram
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+type (
+	// UploadCache keeps identifiers of uploaded files by their keys, so the same content is uploaded only once.
+	// File identifiers are unique for each bot, so one cache must not be shared between bots.
+	UploadCache interface {
+		// Get returns the file identifier by key or false if it's not cached.
+		Get(ctx context.Context, key string) (string, bool, error)
+
+		// Set stores the file identifier by key.
+		Set(ctx context.Context, key, fileID string) error
+
+		// Delete removes the file identifier by key.
+		Delete(ctx context.Context, key string) error
+	}
+
+	// MemoryUploadCache is an UploadCache which keeps identifiers in memory.
+	MemoryUploadCache struct {
+		ids map[string]string
+		mu  sync.RWMutex
+	}
+
+	// FileUploadCache is an UploadCache which keeps identifiers in a file as JSON object. The file is replaced
+	// atomically on each change.
+	FileUploadCache struct {
+		path string
+		ids  map[string]string
+		mu   sync.Mutex
+	}
+)
+
+// ErrWrongFileID describes rejected file identifier which is unknown or expired.
+var ErrWrongFileID = &Error{Code: http.StatusBadRequest, Description: "file identifier"} //nolint: gochecknoglobals
+
+// SetUploadCache enables replacing of uploads in SendPhoto, SendDocument and SendVideo by identifiers of already
+// uploaded files from cache. Nil cache disables it.
+func (b *Bot) SetUploadCache(c UploadCache) {
+	if b == nil {
+		b = new(Bot)
+	}
+
+	b.uploads = c
+}
+
+// cacheUpload sends the file by cached identifier if it's possible, otherwise uploads it by send and caches the
+// identifier returned by fileID. Cached identifier which is rejected by Telegram is removed and the file is uploaded
+// again.
+func (b Bot) cacheUpload(ctx context.Context, f **InputFile, send func() (*Message, error),
+	fileID func(*Message) string) (*Message, error) {
+	if b.uploads == nil || *f == nil || !(*f).IsAttachment() {
+		return send()
+	}
+
+	key, err := (*f).cacheKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if key == "" {
+		return send()
+	}
+
+	upload := *f
+
+	id, ok, err := b.uploads.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		*f = &InputFile{ID: id}
+
+		result, err := send()
+		if err == nil || !isWrongFileID(err) {
+			return result, err
+		}
+
+		if err = b.uploads.Delete(ctx, key); err != nil {
+			return nil, err
+		}
+
+		*f = upload
+	}
+
+	result, err := send()
+	if err != nil {
+		return nil, err
+	}
+
+	if id = fileID(result); id != "" {
+		if err = b.uploads.Set(ctx, key, id); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// cacheKey returns CacheKey or SHA-256 hash of Attachment content. Attachment is rewound back after hashing. Empty
+// key is returned for attachments which are not io.Seeker or fail on seek, like pipes.
+func (f InputFile) cacheKey() (string, error) {
+	if f.CacheKey != "" {
+		return f.CacheKey, nil
+	}
+
+	s, ok := f.Attachment.(io.Seeker)
+	if !ok {
+		return "", nil
+	}
+
+	offset, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", nil
+	}
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f.Attachment); err != nil {
+		return "", err
+	}
+
+	if _, err = s.Seek(offset, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// isWrongFileID checks that err rejects unknown or expired file identifier.
+func isWrongFileID(err error) bool {
+	return xerrors.Is(err, ErrWrongFileID) ||
+		xerrors.Is(err, &Error{Code: http.StatusBadRequest, Description: "file reference"}) ||
+		xerrors.Is(err, &Error{Code: http.StatusBadRequest, Description: "file_reference"})
+}
+
+// NewMemoryUploadCache creates a new empty MemoryUploadCache.
+func NewMemoryUploadCache() *MemoryUploadCache {
+	return &MemoryUploadCache{ids: make(map[string]string)}
+}
+
+// Get returns the file identifier by key.
+func (c *MemoryUploadCache) Get(_ context.Context, key string) (string, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	id, ok := c.ids[key]
+
+	return id, ok, nil
+}
+
+// Set stores the file identifier by key.
+func (c *MemoryUploadCache) Set(_ context.Context, key, fileID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ids[key] = fileID
+
+	return nil
+}
+
+// Delete removes the file identifier by key.
+func (c *MemoryUploadCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.ids, key)
+
+	return nil
+}
+
+// NewFileUploadCache creates a new FileUploadCache which keeps identifiers in the file by path.
+func NewFileUploadCache(path string) *FileUploadCache { return &FileUploadCache{path: path} }
+
+// Get returns the file identifier by key.
+func (c *FileUploadCache) Get(_ context.Context, key string) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.load(); err != nil {
+		return "", false, err
+	}
+
+	id, ok := c.ids[key]
+
+	return id, ok, nil
+}
+
+// Set stores the file identifier by key.
+func (c *FileUploadCache) Set(_ context.Context, key, fileID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.load(); err != nil {
+		return err
+	}
+
+	c.ids[key] = fileID
+
+	return c.save()
+}
+
+// Delete removes the file identifier by key.
+func (c *FileUploadCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.load(); err != nil {
+		return err
+	}
+
+	delete(c.ids, key)
+
+	return c.save()
+}
+
+// load reads the file once. Missing file means empty cache.
+func (c *FileUploadCache) load() error {
+	if c.ids != nil {
+		return nil
+	}
+
+	ids := make(map[string]string)
+
+	src, err := ioutil.ReadFile(c.path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	default:
+		if err = json.Unmarshal(src, &ids); err != nil {
+			return err
+		}
+	}
+
+	c.ids = ids
+
+	return nil
+}
+
+// save writes identifiers into temporary file and renames it to the cache path.
+func (c *FileUploadCache) save() error {
+	src, err := json.Marshal(c.ids)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(src); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
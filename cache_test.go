@@ -0,0 +1,146 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+)
+
+func TestUploadCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "uploads.json")
+
+	for name, c := range map[string]UploadCache{
+		"memory": NewMemoryUploadCache(),
+		"file":   NewFileUploadCache(path),
+	} {
+		c := c
+
+		t.Run(name, func(t *testing.T) {
+			_, ok, err := c.Get(context.Background(), "logo")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			assert.NoError(t, c.Set(context.Background(), "logo", "abc"))
+			assert.NoError(t, c.Set(context.Background(), "sticker", "def"))
+			assert.NoError(t, c.Delete(context.Background(), "sticker"))
+
+			id, ok, err := c.Get(context.Background(), "logo")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, "abc", id)
+		})
+	}
+
+	t.Run("reload", func(t *testing.T) {
+		id, ok, err := NewFileUploadCache(path).Get(context.Background(), "logo")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "abc", id)
+
+		_, ok, err = NewFileUploadCache(path).Get(context.Background(), "sticker")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestBotUploadCache(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		uploads int
+		sent    []string
+		stale   bool
+	)
+
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if form, err := ctx.MultipartForm(); err == nil {
+			assert.Len(t, form.File["photo"], 1)
+
+			uploads++
+			ctx.SetBodyString(`{"ok":true,"result":{"message_id":1,"photo":[` +
+				`{"file_id":"small","width":90,"height":90},{"file_id":"large","width":800,"height":800}]}}`)
+
+			return
+		}
+
+		id := json.ConfigFastest.Get(ctx.PostBody(), "photo").ToString()
+		sent = append(sent, id)
+
+		if stale {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			ctx.SetBodyString(`{"ok":false,"error_code":400,` +
+				`"description":"Bad Request: wrong file identifier/HTTP URL specified"}`)
+
+			return
+		}
+
+		ctx.SetBodyString(`{"ok":true,"result":{"message_id":2}}`)
+	})
+	defer stop()
+
+	cache := NewMemoryUploadCache()
+	b.SetUploadCache(cache)
+
+	send := func() {
+		_, err := b.SendPhoto(NewPhoto(42, &InputFile{
+			Attachment: bytes.NewReader([]byte("logo")),
+			Name:       "logo.png",
+		}))
+		assert.NoError(t, err)
+	}
+
+	send()
+	send()
+
+	mu.Lock()
+	assert.Equal(t, 1, uploads)
+	assert.Equal(t, []string{"large"}, sent)
+	stale = true
+	mu.Unlock()
+
+	send()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, 2, uploads)
+	assert.Equal(t, []string{"large", "large"}, sent)
+}
+
+func TestInputFileCacheKey(t *testing.T) {
+	t.Run("seeker", func(t *testing.T) {
+		f := InputFile{Attachment: bytes.NewReader([]byte("logo"))}
+		key, err := f.cacheKey()
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key, "sha256:"))
+	})
+	t.Run("pipe", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer r.Close()
+		defer w.Close()
+
+		key, err := InputFile{Attachment: r}.cacheKey()
+		assert.NoError(t, err)
+		assert.Empty(t, key)
+	})
+}
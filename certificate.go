@@ -0,0 +1,94 @@
+package telegram
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// ErrInvalidCertificate describes a certificate file which does not contain PEM-encoded certificate.
+var ErrInvalidCertificate = xerrors.New("invalid PEM certificate") //nolint: gochecknoglobals
+
+// NewCertificate generates a self-signed certificate and RSA key for the webhook host (domain or IP address) which
+// are valid for the provided duration. Both are returned PEM-encoded, ready for serving TLS and uploading the
+// certificate by SetWebhook.
+func NewCertificate(host string, validFor time.Duration) (cert, key []byte, err error) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validFor),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &pk.PublicKey, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	key = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(pk)})
+
+	return cert, key, nil
+}
+
+// WriteCertificate generates a self-signed certificate and key by NewCertificate and writes them into files.
+func WriteCertificate(certFile, keyFile, host string, validFor time.Duration) error {
+	cert, key, err := NewCertificate(host, validFor)
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(certFile, cert, 0644); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(keyFile, key, 0600)
+}
+
+// IsSelfSigned checks that PEM-encoded certificate is signed by itself.
+func IsSelfSigned(src []byte) (bool, error) {
+	block, _ := pem.Decode(src)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return false, ErrInvalidCertificate
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false, err
+	}
+
+	if !bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return false, nil
+	}
+
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil, nil
+}
@@ -0,0 +1,67 @@
+package telegram
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCertificate(t *testing.T) {
+	for _, host := range []string{"203.0.113.1", "example.com"} {
+		host := host
+
+		t.Run(host, func(t *testing.T) {
+			cert, key, err := NewCertificate(host, time.Hour)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			block, _ := pem.Decode(cert)
+			if !assert.NotNil(t, block) {
+				t.FailNow()
+			}
+
+			crt, err := x509.ParseCertificate(block.Bytes)
+			assert.NoError(t, err)
+			assert.NoError(t, crt.VerifyHostname(host))
+			assert.Equal(t, host, crt.Subject.CommonName)
+
+			block, _ = pem.Decode(key)
+			if assert.NotNil(t, block) {
+				_, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+				assert.NoError(t, err)
+			}
+
+			ok, err := IsSelfSigned(cert)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := IsSelfSigned([]byte("not a certificate"))
+		assert.Equal(t, ErrInvalidCertificate, err)
+	})
+}
+
+func TestWriteCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cert")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "cert.key")
+	assert.NoError(t, WriteCertificate(certFile, keyFile, "example.com", time.Hour))
+
+	f, err := openSelfSigned(certFile)
+	if assert.NoError(t, err) && assert.NotNil(t, f) {
+		f.Close()
+	}
+}
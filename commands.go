@@ -0,0 +1,376 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+type (
+	// Commands is a registry of bot commands. It parses and validates arguments of incoming commands, replies with
+	// usage text on invalid input, generates /help and keeps the bot's commands list in sync.
+	Commands struct {
+		bot      *Bot
+		commands map[string]*CommandDefinition
+		order    []string
+	}
+
+	// CommandDefinition describes a bot command.
+	CommandDefinition struct {
+		// Name of the command without slash, 1-32 characters. Can contain only lowercase English letters, digits
+		// and underscores.
+		Name string
+
+		// Description of the command, 3-256 characters.
+		Description string
+
+		// Positional arguments of the command. Optional arguments must follow the required ones, argument with
+		// ArgText type can be only the last one and takes the rest of the text as is.
+		Args []CommandArg
+
+		// Handler of the command with parsed arguments.
+		Handler CommandHandler
+	}
+
+	// CommandArg describes a positional argument of a command.
+	CommandArg struct {
+		// Name of the argument used in usage text and as a key of CommandArgs.
+		Name string
+
+		// Type of the argument, one of Arg* constants. Defaults to ArgString.
+		Type string
+
+		// True, if the argument can be omitted
+		Optional bool
+	}
+
+	// CommandHandler handles the command message with parsed arguments.
+	CommandHandler func(ctx context.Context, m *Message, args CommandArgs) error
+
+	// CommandArgs contains parsed command arguments by names.
+	CommandArgs map[string]interface{}
+)
+
+// NewCommands creates a new empty Commands registry for the bot. The /help command is generated automatically
+// unless it's registered manually.
+func NewCommands(b *Bot) *Commands {
+	return &Commands{
+		bot:      b,
+		commands: make(map[string]*CommandDefinition),
+		order:    make([]string, 0),
+	}
+}
+
+// Register adds command definition into registry. Registering a command with the same name replaces previous one.
+func (c *Commands) Register(def CommandDefinition) error {
+	def.Name = strings.ToLower(strings.TrimPrefix(def.Name, "/"))
+	if def.Name == "" || def.Handler == nil {
+		return xerrors.New("command must have a name and a handler")
+	}
+
+	if len(def.Name) > 32 || strings.IndexFunc(def.Name, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_'
+	}) >= 0 {
+		return xerrors.Errorf("command /%s: name must be 1-32 lowercase English letters, digits or underscores",
+			def.Name)
+	}
+
+	if length := utf8.RuneCountInString(def.Description); length < 3 || length > 256 {
+		return xerrors.Errorf("command /%s: description must be 3-256 characters", def.Name)
+	}
+
+	for i := range def.Args {
+		if def.Args[i].Type == "" {
+			def.Args[i].Type = ArgString
+		}
+
+		switch def.Args[i].Type {
+		case ArgDuration, ArgInt, ArgMention, ArgString:
+		case ArgText:
+			if i != len(def.Args)-1 {
+				return xerrors.Errorf("argument %s of /%s: text argument must be the last one", def.Args[i].Name,
+					def.Name)
+			}
+		default:
+			return xerrors.Errorf("argument %s of /%s: unsupported type %s", def.Args[i].Name, def.Name,
+				def.Args[i].Type)
+		}
+
+		if i > 0 && def.Args[i-1].Optional && !def.Args[i].Optional {
+			return xerrors.Errorf("argument %s of /%s: required argument after optional one", def.Args[i].Name,
+				def.Name)
+		}
+	}
+
+	if _, ok := c.commands[def.Name]; !ok {
+		c.order = append(c.order, def.Name)
+	}
+
+	c.commands[def.Name] = &def
+
+	return nil
+}
+
+// Sync replaces the list of the bot's commands by registered ones, so clients menu always matches the code.
+func (c *Commands) Sync(ctx context.Context) error {
+	_, err := c.bot.SetMyCommandsContext(ctx, SetMyCommands{Commands: c.BotCommands()})
+
+	return err
+}
+
+// BotCommands returns the list of registered commands including generated /help.
+func (c *Commands) BotCommands() []*BotCommand {
+	result := make([]*BotCommand, 0, len(c.order)+1)
+	for _, name := range c.order {
+		result = append(result, &BotCommand{Command: name, Description: c.commands[name].Description})
+	}
+
+	if _, ok := c.commands[CommandHelp]; !ok {
+		result = append(result, &BotCommand{Command: CommandHelp, Description: "Show available commands"})
+	}
+
+	return result
+}
+
+// Filter creates a filter which matches messages with registered commands addressed to the bot.
+func (c *Commands) Filter() Filter {
+	return func(u *Update) bool {
+		m := u.EffectiveMessage()
+
+		return m != nil && c.lookup(m) != nil
+	}
+}
+
+// Mount registers the registry as a new messages handler of the router.
+func (c *Commands) Mount(r *Router) { r.OnMessage(c.HandleMessage, c.Filter()) }
+
+// HandleMessage parses the command message and calls the handler of the command. Messages with unknown commands
+// are ignored. If arguments are invalid, the usage text of the command is sent as reply.
+func (c *Commands) HandleMessage(ctx context.Context, m *Message) error {
+	def := c.lookup(m)
+	if def == nil {
+		return nil
+	}
+
+	if def.Name == CommandHelp && def.Handler == nil {
+		return c.reply(ctx, m, c.Help())
+	}
+
+	args, err := def.Parse(m.CommandArgument())
+	if err != nil {
+		return c.reply(ctx, m, err.Error()+"\nUsage: "+def.Usage())
+	}
+
+	return def.Handler(ctx, m, args)
+}
+
+// Help returns the generated text of /help command.
+func (c *Commands) Help() string {
+	var b strings.Builder
+
+	names := make([]string, len(c.order))
+	copy(names, c.order)
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s - %s\n", c.commands[name].Usage(), c.commands[name].Description)
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func (c *Commands) lookup(m *Message) *CommandDefinition {
+	if !m.IsCommand() {
+		return nil
+	}
+
+	if parts := strings.SplitN(m.RawCommand(), "@", 2); len(parts) == 2 &&
+		(c.bot == nil || c.bot.User == nil || !strings.EqualFold(parts[1], c.bot.Username)) {
+		return nil
+	}
+
+	name := strings.ToLower(m.Command())
+	if def, ok := c.commands[name]; ok {
+		return def
+	}
+
+	if name == CommandHelp {
+		return &CommandDefinition{Name: CommandHelp}
+	}
+
+	return nil
+}
+
+func (c *Commands) reply(ctx context.Context, m *Message, text string) error {
+	p := NewMessage(m.Chat.ID, text)
+	p.ReplyToMessageID = m.ID
+	_, err := c.bot.SendMessageContext(ctx, p)
+
+	return err
+}
+
+// Usage returns the usage text of the command, like "/remind <duration> [text]".
+func (def CommandDefinition) Usage() string {
+	var b strings.Builder
+
+	b.WriteString("/" + def.Name)
+
+	for _, arg := range def.Args {
+		if arg.Optional {
+			b.WriteString(" [" + arg.Name + "]")
+		} else {
+			b.WriteString(" <" + arg.Name + ">")
+		}
+	}
+
+	return b.String()
+}
+
+// Parse parses and validates raw command argument by the command definition.
+func (def CommandDefinition) Parse(raw string) (CommandArgs, error) {
+	n := -1
+	if len(def.Args) > 0 && def.Args[len(def.Args)-1].Type == ArgText {
+		n = len(def.Args)
+	}
+
+	tokens, err := splitArgsN(raw, n)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(CommandArgs, len(def.Args))
+
+	for i, arg := range def.Args {
+		if i >= len(tokens) {
+			if !arg.Optional {
+				return nil, xerrors.Errorf("missing argument %s", arg.Name)
+			}
+
+			break
+		}
+
+		if arg.Type == ArgText {
+			result[arg.Name] = tokens[i]
+
+			break
+		}
+
+		if result[arg.Name], err = parseArg(arg, tokens[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tokens) > len(def.Args) {
+		return nil, xerrors.New("too many arguments")
+	}
+
+	return result, nil
+}
+
+// Has checks that the argument is present.
+func (a CommandArgs) Has(name string) bool {
+	_, ok := a[name]
+
+	return ok
+}
+
+// String returns the value of string, text or mention argument.
+func (a CommandArgs) String(name string) string {
+	s, _ := a[name].(string)
+
+	return s
+}
+
+// Int returns the value of int argument.
+func (a CommandArgs) Int(name string) int {
+	i, _ := a[name].(int)
+
+	return i
+}
+
+// Duration returns the value of duration argument.
+func (a CommandArgs) Duration(name string) time.Duration {
+	d, _ := a[name].(time.Duration)
+
+	return d
+}
+
+func parseArg(arg CommandArg, token string) (interface{}, error) {
+	switch arg.Type {
+	case ArgInt:
+		i, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, xerrors.Errorf("argument %s must be an integer", arg.Name)
+		}
+
+		return i, nil
+	case ArgDuration:
+		d, err := time.ParseDuration(token)
+		if err != nil {
+			return nil, xerrors.Errorf("argument %s must be a duration like 1h30m", arg.Name)
+		}
+
+		return d, nil
+	case ArgMention:
+		if len(token) < 2 || token[0] != '@' {
+			return nil, xerrors.Errorf("argument %s must be a @username", arg.Name)
+		}
+
+		return token[1:], nil
+	default:
+		return token, nil
+	}
+}
+
+// splitArgsN splits raw argument by spaces into at most n arguments, the last one is the unparsed rest of raw.
+// Negative n means no limit. Single or double quotes can be used for arguments with spaces.
+func splitArgsN(raw string, n int) ([]string, error) {
+	result := make([]string, 0)
+
+	var (
+		token  strings.Builder
+		quote  rune
+		quoted bool
+	)
+
+	for i, r := range raw {
+		if n > 0 && len(result) == n-1 && quote == 0 && !quoted && token.Len() == 0 && !unicode.IsSpace(r) {
+			return append(result, raw[i:]), nil
+		}
+
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			token.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, quoted = r, true
+		case unicode.IsSpace(r):
+			if token.Len() > 0 || quoted {
+				result = append(result, token.String())
+			}
+
+			token.Reset()
+			quoted = false
+		default:
+			token.WriteRune(r)
+		}
+	}
+
+	if quote != 0 {
+		return nil, xerrors.New("unterminated quote")
+	}
+
+	if token.Len() > 0 || quoted {
+		result = append(result, token.String())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,142 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+)
+
+func TestSplitArgs(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		raw       string
+		n         int
+		expResult []string
+		expError  bool
+	}{
+		{name: "empty", raw: "", expResult: []string{}},
+		{name: "plain", raw: " a  b c", expResult: []string{"a", "b", "c"}},
+		{name: "quoted", raw: `"hello world" 'it''s' ""`, expResult: []string{"hello world", "its", ""}},
+		{name: "unterminated", raw: `"hello`, expError: true},
+		{name: "limited", raw: ` "a b"  c  'd'`, n: 2, expResult: []string{"a b", "c  'd'"}},
+		{name: "limited unterminated", raw: `a "b`, n: 2, expResult: []string{"a", `"b`}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.n == 0 {
+				tc.n = -1
+			}
+
+			result, err := splitArgsN(tc.raw, tc.n)
+			if tc.expError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expResult, result)
+		})
+	}
+}
+
+func TestCommandDefinitionParse(t *testing.T) {
+	def := CommandDefinition{
+		Name: "remind",
+		Args: []CommandArg{
+			{Name: "who", Type: ArgMention},
+			{Name: "after", Type: ArgDuration},
+			{Name: "times", Type: ArgInt, Optional: true},
+			{Name: "text", Type: ArgText, Optional: true},
+		},
+	}
+
+	assert.Equal(t, "/remind <who> <after> [times] [text]", def.Usage())
+
+	args, err := def.Parse("@toby3d 1h30m 2 buy  \"some\"\nmilk")
+	assert.NoError(t, err)
+	assert.Equal(t, "toby3d", args.String("who"))
+	assert.Equal(t, 90*time.Minute, args.Duration("after"))
+	assert.Equal(t, 2, args.Int("times"))
+	assert.Equal(t, "buy  \"some\"\nmilk", args.String("text"))
+
+	args, err = def.Parse("@toby3d 5m")
+	assert.NoError(t, err)
+	assert.False(t, args.Has("times"))
+
+	for _, raw := range []string{"", "toby3d 5m", "@toby3d soon", "@toby3d 5m many"} {
+		_, err = def.Parse(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestCommandsHandleMessage(t *testing.T) {
+	var replies []string
+
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		replies = append(replies, string(ctx.Request.Body()))
+		ctx.SetBodyString(`{"ok":true,"result":{"message_id":2}}`)
+	})
+	defer stop()
+
+	b.User = &User{Username: "TestBot"}
+
+	var sum int
+
+	c := NewCommands(b)
+	assert.NoError(t, c.Register(CommandDefinition{
+		Name:        "add",
+		Description: "Add two numbers",
+		Args:        []CommandArg{{Name: "a", Type: ArgInt}, {Name: "b", Type: ArgInt}},
+		Handler: func(ctx context.Context, m *Message, args CommandArgs) error {
+			sum = args.Int("a") + args.Int("b")
+			return nil
+		},
+	}))
+	assert.Error(t, c.Register(CommandDefinition{Name: "broken"}))
+
+	noop := func(context.Context, *Message, CommandArgs) error { return nil }
+	for _, def := range []CommandDefinition{
+		{Name: "bad-name", Description: "Broken command", Handler: noop},
+		{Name: "команда", Description: "Broken command", Handler: noop},
+		{Name: strings.Repeat("a", 33), Description: "Broken command", Handler: noop},
+		{Name: "broken", Description: "no", Handler: noop},
+		{Name: "broken", Description: strings.Repeat("a", 257), Handler: noop},
+	} {
+		assert.Error(t, c.Register(def), def.Name)
+	}
+
+	assert.Equal(t, []*BotCommand{
+		{Command: "add", Description: "Add two numbers"},
+		{Command: CommandHelp, Description: "Show available commands"},
+	}, c.BotCommands())
+
+	newCommand := func(text string, length int) *Message {
+		return &Message{
+			ID:       1,
+			Text:     text,
+			Chat:     &Chat{ID: 42, Type: ChatGroup},
+			Entities: []*MessageEntity{{Type: EntityBotCommand, Length: length}},
+		}
+	}
+
+	ctx := context.Background()
+
+	assert.NoError(t, c.HandleMessage(ctx, newCommand("/add@TestBot 2 3", 12)))
+	assert.Equal(t, 5, sum)
+
+	assert.NoError(t, c.HandleMessage(ctx, newCommand("/add@OtherBot 4 4", 13)))
+	assert.Equal(t, 5, sum)
+	assert.False(t, c.Filter()(&Update{Message: newCommand("/add@OtherBot 4 4", 13)}))
+
+	assert.NoError(t, c.HandleMessage(ctx, newCommand("/add two", 4)))
+	assert.NoError(t, c.HandleMessage(ctx, newCommand("/help", 5)))
+
+	if assert.Len(t, replies, 2) {
+		assert.Contains(t, replies[0], "argument a must be an integer\\nUsage: /add <a> <b>")
+		assert.Contains(t, replies[1], "/add <a> <b> - Add two numbers")
+	}
+}
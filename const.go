@@ -27,6 +27,15 @@ const (
 	ChatSuperGroup string = "supergroup"
 )
 
+// Arg represents available and supported types of command arguments
+const (
+	ArgDuration string = "duration"
+	ArgInt      string = "int"
+	ArgMention  string = "mention"
+	ArgString   string = "string"
+	ArgText     string = "text"
+)
+
 // Command represents global commands which should be supported by any bot. You can user IsCommandEqual method of Message for checking.
 //
 // See: https://core.telegram.org/bots#global-commands
@@ -154,9 +163,23 @@ const (
 // Scheme represents optional schemes for URLs
 const (
 	SchemeAttach   string = "attach"
+	SchemeFile     string = "file"
 	SchemeTelegram string = "tg"
 )
 
+// Source represents available and supported sources of Telegram Passport element errors
+const (
+	SourceData             string = "data"
+	SourceFile             string = "file"
+	SourceFiles            string = "files"
+	SourceFrontSide        string = "front_side"
+	SourceReverseSide      string = "reverse_side"
+	SourceSelfie           string = "selfie"
+	SourceTranslationFile  string = "translation_file"
+	SourceTranslationFiles string = "translation_files"
+	SourceUnspecified      string = "unspecified"
+)
+
 // Status represents available and supported statuses of ID
 const (
 	StatusAdministrator string = "administrator"
@@ -207,6 +230,7 @@ const (
 	UpdateInlineQuery        string = "inline_query"
 	UpdateMessage            string = "message"
 	UpdatePoll               string = "poll"
+	UpdatePollAnswer         string = "poll_answer"
 	UpdatePreCheckoutQuery   string = "pre_checkout_query"
 	UpdateShippingQuery      string = "shipping_query"
 )
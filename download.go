@@ -0,0 +1,129 @@
+package telegram
+
+import (
+	"context"
+	"io"
+	"os"
+
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+// MaxDownloadSize is the maximum size of file which can be downloaded from the Bot API server. The limit is not
+// applied in local mode.
+const MaxDownloadSize int = 20 << 20
+
+// Download errors.
+var (
+	ErrFileTooBig = xerrors.New("file is too big for download")                        //nolint: gochecknoglobals
+	ErrFileSize   = xerrors.New("size of downloaded file does not match its FileSize") //nolint: gochecknoglobals
+	ErrFilePath   = xerrors.New("file has no path for download")                       //nolint: gochecknoglobals
+)
+
+// sizeReader checks that the size of read content does not exceed limit and equals expected size, if it's known.
+type sizeReader struct {
+	io.ReadCloser
+	read, size, limit int64
+}
+
+// Download gets file by the fileID and writes its content into w.
+func (b Bot) Download(ctx context.Context, fileID string, w io.Writer) error {
+	r, err := b.DownloadFile(ctx, &File{FileID: fileID})
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	_, err = io.Copy(w, r)
+
+	return err
+}
+
+// DownloadFile returns the content of file. File without FilePath (like result of Audio.File or
+// ChatPhoto.BigFile) is requested by GetFile first. Files bigger than MaxDownloadSize are rejected by ErrFileTooBig
+// and files with unexpected size fails on read with ErrFileSize. Returned io.ReadCloser must be closed by caller.
+func (b Bot) DownloadFile(ctx context.Context, f *File) (io.ReadCloser, error) {
+	if f.FilePath == "" {
+		file, err := b.GetFileContext(ctx, f.FileID)
+		if err != nil {
+			return nil, err
+		}
+
+		if file.FileSize == 0 {
+			file.FileSize = f.FileSize
+		}
+
+		f = file
+	}
+
+	limit := int64(-1)
+	if !b.local {
+		limit = int64(MaxDownloadSize)
+	}
+
+	if limit >= 0 && int64(f.FileSize) > limit {
+		return nil, ErrFileTooBig
+	}
+
+	u := b.NewFileURL(f.FilePath)
+	if u == nil {
+		return nil, ErrFilePath
+	}
+	defer http.ReleaseURI(u)
+
+	var (
+		body io.ReadCloser
+		err  error
+	)
+
+	if string(u.Scheme()) == SchemeFile {
+		body, err = os.Open(string(u.Path()))
+	} else {
+		body, err = b.get(ctx, u.String())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sizeReader{ReadCloser: body, size: int64(f.FileSize), limit: limit}, nil
+}
+
+// get requests content by the uri and returns its body on success.
+func (b Bot) get(ctx context.Context, uri string) (io.ReadCloser, error) {
+	t := b.transport
+	if t == nil {
+		t = defaultTransport
+	}
+
+	resp, err := t.Do(ctx, &TransportRequest{Method: http.MethodGet, URI: uri, ContentLength: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+
+		return nil, &Error{
+			Code:        resp.StatusCode,
+			Description: http.StatusMessage(resp.StatusCode),
+			frame:       xerrors.Caller(1),
+		}
+	}
+
+	return resp.Body, nil
+}
+
+func (r *sizeReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.read += int64(n)
+
+	switch {
+	case r.limit >= 0 && r.read > r.limit:
+		return n, ErrFileTooBig
+	case r.size > 0 && r.read > r.size, err == io.EOF && r.size > 0 && r.read != r.size:
+		return n, ErrFileSize
+	default:
+		return n, err
+	}
+}
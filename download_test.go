@@ -0,0 +1,72 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+func TestBotDownload(t *testing.T) {
+	content := "hello"
+
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		switch string(ctx.Path()) {
+		case "/bot123:abc/getFile":
+			ctx.SetBodyString(`{"ok":true,"result":{"file_id":"abc","file_size":5,"file_path":"docs/a.txt"}}`)
+		case "/file/bot123:abc/docs/a.txt":
+			ctx.SetBodyString(content)
+		default:
+			ctx.SetStatusCode(http.StatusNotFound)
+		}
+	})
+	defer stop()
+
+	t.Run("ok", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		assert.NoError(t, b.Download(context.Background(), "abc", buf))
+		assert.Equal(t, content, buf.String())
+	})
+	t.Run("size", func(t *testing.T) {
+		content = "hell"
+		defer func() { content = "hello" }()
+
+		assert.True(t, xerrors.Is(b.Download(context.Background(), "abc", new(bytes.Buffer)), ErrFileSize))
+	})
+	t.Run("too big", func(t *testing.T) {
+		_, err := b.DownloadFile(context.Background(), &File{FilePath: "docs/b.mp4", FileSize: MaxDownloadSize + 1})
+		assert.Equal(t, ErrFileTooBig, err)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := b.DownloadFile(context.Background(), &File{FilePath: "docs/c.txt"})
+		assert.True(t, xerrors.Is(err, ErrNotFound))
+	})
+	t.Run("local", func(t *testing.T) {
+		file, err := ioutil.TempFile("", "download")
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer os.Remove(file.Name())
+
+		_, _ = file.WriteString(content)
+		file.Close()
+
+		b.SetLocalMode(true)
+		defer b.SetLocalMode(false)
+
+		r, err := b.DownloadFile(context.Background(), &File{FilePath: file.Name(), FileSize: len(content)})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer r.Close()
+
+		src, err := ioutil.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(src))
+	})
+}
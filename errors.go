@@ -2,17 +2,46 @@ package telegram
 
 import (
 	"fmt"
+	"strings"
 
+	http "github.com/valyala/fasthttp"
 	"golang.org/x/xerrors"
 )
 
+// Error represents an unsuccessful Telegram API response.
 type Error struct {
-	Code        int                   `json:"error_code"`
-	Description string                `json:"description"`
-	Parameters  []*ResponseParameters `json:"parameters,omitempty"`
+	Code        int                 `json:"error_code"`
+	Description string              `json:"description"`
+	Parameters  *ResponseParameters `json:"parameters,omitempty"`
 	frame       xerrors.Frame
 }
 
+// Known errors which can be checked via errors.Is (or xerrors.Is) with any error returned by Bot methods.
+//
+// Sentinels with an empty Description match any error with the same Code, others also match by a case insensitive
+// substring of the Description.
+var (
+	ErrBadRequest         = &Error{Code: http.StatusBadRequest}                                            //nolint: gochecknoglobals
+	ErrUnauthorized       = &Error{Code: http.StatusUnauthorized}                                          //nolint: gochecknoglobals
+	ErrForbidden          = &Error{Code: http.StatusForbidden}                                             //nolint: gochecknoglobals
+	ErrNotFound           = &Error{Code: http.StatusNotFound}                                              //nolint: gochecknoglobals
+	ErrConflict           = &Error{Code: http.StatusConflict}                                              //nolint: gochecknoglobals
+	ErrTooManyRequests    = &Error{Code: http.StatusTooManyRequests}                                       //nolint: gochecknoglobals
+	ErrChatNotFound       = &Error{Code: http.StatusBadRequest, Description: "chat not found"}             //nolint: gochecknoglobals
+	ErrMessageNotModified = &Error{Code: http.StatusBadRequest, Description: "message is not modified"}    //nolint: gochecknoglobals
+	ErrBotBlocked         = &Error{Code: http.StatusForbidden, Description: "bot was blocked by the user"} //nolint: gochecknoglobals
+)
+
+// newError creates a new Error from unsuccessful response.
+func newError(resp *Response) *Error {
+	return &Error{
+		Code:        resp.ErrorCode,
+		Description: resp.Description,
+		Parameters:  resp.Parameters,
+		frame:       xerrors.Caller(1),
+	}
+}
+
 func (e Error) FormatError(p xerrors.Printer) error {
 	p.Printf("%d %s", e.Code, e.Description)
 	e.frame.Format(p)
@@ -27,3 +56,42 @@ func (e Error) Format(s fmt.State, r rune) {
 func (e Error) Error() string {
 	return fmt.Sprint(e)
 }
+
+// Is reports whether the current error matches the target Error by code and description.
+func (e Error) Is(target error) bool {
+	var t *Error
+
+	switch err := target.(type) {
+	case *Error:
+		t = err
+	case Error:
+		t = &err
+	default:
+		return false
+	}
+
+	if t.Code != 0 && t.Code != e.Code {
+		return false
+	}
+
+	return t.Description == "" ||
+		strings.Contains(strings.ToLower(e.Description), strings.ToLower(t.Description))
+}
+
+// RetryAfter returns the number of seconds left to wait before the request can be repeated, if any.
+func (e Error) RetryAfter() int {
+	if e.Parameters == nil {
+		return 0
+	}
+
+	return e.Parameters.RetryAfter
+}
+
+// MigrateToChatID returns the identifier of the supergroup to which the group has been migrated, if any.
+func (e Error) MigrateToChatID() int64 {
+	if e.Parameters == nil {
+		return 0
+	}
+
+	return e.Parameters.MigrateToChatID
+}
@@ -0,0 +1,44 @@
+package telegram
+
+import (
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+func TestErrorIs(t *testing.T) {
+	err := &Error{Code: http.StatusBadRequest, Description: "Bad Request: chat not found"}
+
+	assert.True(t, xerrors.Is(err, ErrBadRequest))
+	assert.True(t, xerrors.Is(err, ErrChatNotFound))
+	assert.False(t, xerrors.Is(err, ErrMessageNotModified))
+	assert.False(t, xerrors.Is(err, ErrForbidden))
+	assert.True(t, xerrors.Is(xerrors.Errorf("wrapped: %w", err), ErrChatNotFound))
+}
+
+func TestBotDecode(t *testing.T) {
+	b := Bot{marshler: json.ConfigFastest}
+
+	t.Run("ok", func(t *testing.T) {
+		result, err := b.decode(http.StatusOK, []byte(`{"ok":true,"result":true}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "true", string(result.Result))
+	})
+	t.Run("error", func(t *testing.T) {
+		_, err := b.decode(http.StatusTooManyRequests, []byte(`{"ok":false,"error_code":429,"description":`+
+			`"Too Many Requests: retry after 5","parameters":{"retry_after":5}}`))
+		assert.True(t, xerrors.Is(err, ErrTooManyRequests))
+
+		var tgErr *Error
+		if assert.True(t, xerrors.As(err, &tgErr)) {
+			assert.Equal(t, 5, tgErr.RetryAfter())
+		}
+	})
+	t.Run("not json", func(t *testing.T) {
+		_, err := b.decode(http.StatusBadGateway, []byte("<html>502 Bad Gateway</html>"))
+		assert.True(t, xerrors.Is(err, &Error{Code: http.StatusBadGateway}))
+	})
+}
@@ -1,5 +1,7 @@
 package telegram
 
+import "context"
+
 type (
 	// Game represents a game. Use BotFather to create and edit games, their short names will act as unique identifiers.
 	Game struct {
@@ -104,13 +106,13 @@ func NewGame(chatID int64, gameShortName string) SendGame {
 
 // SendGame send a game. On success, the sent Message is returned.
 func (b Bot) SendGame(p SendGame) (*Message, error) {
-	src, err := b.Do(MethodSendGame, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SendGameContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendGameContext is the same as SendGame, but with a context.Context.
+func (b Bot) SendGameContext(ctx context.Context, p SendGame) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSendGame, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -131,13 +133,13 @@ func NewGameScore(userID int, score int) SetGameScore {
 
 // SetGameScore set the score of the specified user in a game. On success, if the message was sent by the bot, returns the edited Message, otherwise returns True. Returns an error, if the new score is not greater than the user's current score in the chat and force is False.
 func (b Bot) SetGameScore(p SetGameScore) (*Message, error) {
-	src, err := b.Do(MethodSetGameScore, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SetGameScoreContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SetGameScoreContext is the same as SetGameScore, but with a context.Context.
+func (b Bot) SetGameScoreContext(ctx context.Context, p SetGameScore) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSetGameScore, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -151,13 +153,13 @@ func (b Bot) SetGameScore(p SetGameScore) (*Message, error) {
 
 // GetGameHighScores get data for high score tables. Will return the score of the specified user and several of his neighbors in a game. On success, returns an Array of GameHighScore objects.
 func (b Bot) GetGameHighScores(p GetGameHighScores) ([]*GameHighScore, error) {
-	src, err := b.Do(MethodGetGameHighScores, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.GetGameHighScoresContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetGameHighScoresContext is the same as GetGameHighScores, but with a context.Context.
+func (b Bot) GetGameHighScoresContext(ctx context.Context, p GetGameHighScores) ([]*GameHighScore, error) {
+	resp, err := b.DoContext(ctx, MethodGetGameHighScores, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,166 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+type (
+	// Handler handles incoming update.
+	Handler interface {
+		HandleUpdate(ctx context.Context, u *Update) error
+	}
+
+	// HandlerFunc is an adapter to allow the use of ordinary functions as Handler.
+	HandlerFunc func(ctx context.Context, u *Update) error
+
+	// Middleware wraps Handler by some cross-cutting logic.
+	Middleware func(next Handler) Handler
+
+	// PanicError represents a panic recovered while handling the update.
+	PanicError struct {
+		// Identifier of the update which caused panic
+		UpdateID int
+
+		// Value passed to panic
+		Value interface{}
+
+		// Stack trace of the panicked goroutine
+		Stack []byte
+	}
+
+	// LogEntry contains information about the handled update.
+	LogEntry struct {
+		UpdateID int
+		Type     string
+		ChatID   int64
+		UserID   int
+		Duration time.Duration
+		Err      error
+	}
+)
+
+// HandleUpdate calls f(ctx, u).
+func (f HandlerFunc) HandleUpdate(ctx context.Context, u *Update) error { return f(ctx, u) }
+
+// Chain wraps the handler by middlewares. The first middleware is the outermost one.
+func Chain(h Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+
+	return h
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("panic while handling update %d: %v", e.UpdateID, e.Value)
+}
+
+// Recovery creates a middleware which recovers panics of the next handlers and returns them as *PanicError.
+func Recovery() Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, u *Update) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = &PanicError{UpdateID: u.UpdateID, Value: r, Stack: debug.Stack()}
+				}
+			}()
+
+			return next.HandleUpdate(ctx, u)
+		})
+	}
+}
+
+// Logging creates a middleware which calls log with information about each handled update.
+func Logging(log func(ctx context.Context, entry LogEntry)) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, u *Update) error {
+			start := time.Now()
+			err := next.HandleUpdate(ctx, u)
+			entry := LogEntry{
+				UpdateID: u.UpdateID,
+				Type:     u.Type(),
+				Duration: time.Since(start),
+				Err:      err,
+			}
+
+			if chat := u.EffectiveChat(); chat != nil {
+				entry.ChatID = chat.ID
+			}
+
+			if user := u.EffectiveUser(); user != nil {
+				entry.UserID = user.ID
+			}
+
+			log(ctx, entry)
+
+			return err
+		})
+	}
+}
+
+// Timing creates a middleware which calls observe with the handling duration of each update. Useful for metrics.
+func Timing(observe func(u *Update, d time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, u *Update) error {
+			start := time.Now()
+			err := next.HandleUpdate(ctx, u)
+			observe(u, time.Since(start), err)
+
+			return err
+		})
+	}
+}
+
+// AllowUsers creates a middleware which skips updates from any users except provided ones.
+func AllowUsers(ids ...int) Middleware {
+	allowed := make(map[int]struct{}, len(ids))
+	for i := range ids {
+		allowed[ids[i]] = struct{}{}
+	}
+
+	return Allow(func(u *Update) bool {
+		user := u.EffectiveUser()
+		if user == nil {
+			return false
+		}
+
+		_, ok := allowed[user.ID]
+
+		return ok
+	})
+}
+
+// AllowChats creates a middleware which skips updates from any chats except provided ones.
+func AllowChats(ids ...int64) Middleware {
+	allowed := make(map[int64]struct{}, len(ids))
+	for i := range ids {
+		allowed[ids[i]] = struct{}{}
+	}
+
+	return Allow(func(u *Update) bool {
+		chat := u.EffectiveChat()
+		if chat == nil {
+			return false
+		}
+
+		_, ok := allowed[chat.ID]
+
+		return ok
+	})
+}
+
+// Allow creates a middleware which skips updates which does not match the filter.
+func Allow(filter Filter) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, u *Update) error {
+			if !filter(u) {
+				return nil
+			}
+
+			return next.HandleUpdate(ctx, u)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(ctx context.Context, u *Update) error {
+				calls = append(calls, name)
+				return next.HandleUpdate(ctx, u)
+			})
+		}
+	}
+
+	h := Chain(HandlerFunc(func(ctx context.Context, u *Update) error {
+		calls = append(calls, "handler")
+		return nil
+	}), trace("first"), trace("second"))
+
+	assert.NoError(t, h.HandleUpdate(context.Background(), new(Update)))
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+}
+
+func TestRecovery(t *testing.T) {
+	h := Chain(HandlerFunc(func(ctx context.Context, u *Update) error { panic("oops") }), Recovery())
+
+	err := h.HandleUpdate(context.Background(), &Update{UpdateID: 42})
+
+	var panicErr *PanicError
+	if assert.True(t, xerrors.As(err, &panicErr)) {
+		assert.Equal(t, 42, panicErr.UpdateID)
+		assert.Equal(t, "oops", panicErr.Value)
+		assert.NotEmpty(t, panicErr.Stack)
+	}
+}
+
+func TestAllowChats(t *testing.T) {
+	var handled bool
+
+	h := Chain(HandlerFunc(func(ctx context.Context, u *Update) error {
+		handled = true
+		return nil
+	}), AllowChats(42))
+
+	assert.NoError(t, h.HandleUpdate(context.Background(), &Update{Message: &Message{Chat: &Chat{ID: 24}}}))
+	assert.False(t, handled)
+	assert.NoError(t, h.HandleUpdate(context.Background(), &Update{Message: &Message{Chat: &Chat{ID: 42}}}))
+	assert.True(t, handled)
+}
+
+func TestLogging(t *testing.T) {
+	var entry LogEntry
+
+	errHandler := xerrors.New("handler error")
+	h := Chain(HandlerFunc(func(ctx context.Context, u *Update) error { return errHandler }),
+		Logging(func(ctx context.Context, e LogEntry) { entry = e }))
+
+	assert.Equal(t, errHandler, h.HandleUpdate(context.Background(), &Update{
+		UpdateID:      1,
+		CallbackQuery: &CallbackQuery{From: &User{ID: 2}, Message: &Message{Chat: &Chat{ID: 3}}},
+	}))
+	assert.Equal(t, 1, entry.UpdateID)
+	assert.Equal(t, UpdateCallbackQuery, entry.Type)
+	assert.Equal(t, int64(3), entry.ChatID)
+	assert.Equal(t, 2, entry.UserID)
+	assert.True(t, entry.Duration >= time.Duration(0))
+	assert.Equal(t, errHandler, entry.Err)
+}
@@ -1,5 +1,7 @@
 package telegram
 
+import "context"
+
 type (
 	// InlineQuery represents an incoming inline query. When the user sends an empty query, your bot could return some default or trending results.
 	InlineQuery struct {
@@ -788,13 +790,13 @@ func NewAnswerInline(inlineQueryID string, results ...InlineQueryResult) AnswerI
 //
 // No more than 50 results per query are allowed.
 func (b Bot) AnswerInlineQuery(p AnswerInlineQuery) (bool, error) {
-	src, err := b.Do(MethodAnswerInlineQuery, p)
-	if err != nil {
-		return false, err
-	}
+	return b.AnswerInlineQueryContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// AnswerInlineQueryContext is the same as AnswerInlineQuery, but with a context.Context.
+func (b Bot) AnswerInlineQueryContext(ctx context.Context, p AnswerInlineQuery) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodAnswerInlineQuery, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -841,6 +843,14 @@ func NewInlineKeyboardButtonURL(text, url string) *InlineKeyboardButton {
 	}
 }
 
+// NewInlineKeyboardButtonLoginURL creates a new inline keyboard button which authorizes the user by u.
+func NewInlineKeyboardButtonLoginURL(text string, u *LoginURL) *InlineKeyboardButton {
+	return &InlineKeyboardButton{
+		Text:     text,
+		LoginURL: u,
+	}
+}
+
 func NewInlineKeyboardMarkup(rows ...[]*InlineKeyboardButton) InlineKeyboardMarkup {
 	return InlineKeyboardMarkup{InlineKeyboard: rows}
 }
@@ -0,0 +1,248 @@
+package telegram
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// Limiter controls the rate of outgoing requests. It's called by Bot before each request attempt.
+	Limiter interface {
+		// Wait blocks until the request to the method for the chatID is allowed or ctx is done. The chatID is the
+		// chat_id of request as is (identifier or @username) and empty if request is not related to any chat.
+		Wait(ctx context.Context, method string, chatID string) error
+	}
+
+	// Rate represents the number of events allowed per period of time.
+	Rate struct {
+		Count int
+		Per   time.Duration
+	}
+
+	// RateLimiter is a Limiter which keeps token buckets for all sends and for each chat separately. Only sending
+	// methods (see IsSendMethod) are limited, other requests passes as is.
+	RateLimiter struct {
+		global  *bucket
+		private Rate
+		group   Rate
+		chats   map[string]*bucket
+		cleaned time.Time
+		stats   LimiterStats
+		mu      sync.Mutex
+	}
+
+	// LimiterStats contains the current state of RateLimiter.
+	LimiterStats struct {
+		// Number of requests passed through the limiter
+		Requests uint64
+
+		// Number of requests which has been delayed
+		Delayed uint64
+
+		// Number of requests which are waiting right now
+		Waiting int
+
+		// Total time of all delays
+		WaitTime time.Duration
+
+		// Number of chats with tracked buckets
+		Chats int
+
+		// Number of available tokens in the global bucket
+		GlobalTokens float64
+	}
+
+	bucket struct {
+		rate   Rate
+		tokens float64
+		last   time.Time
+	}
+)
+
+// Default limits of sending messages recommended by Telegram.
+//
+// See: https://core.telegram.org/bots/faq#my-bot-is-hitting-limits-how-do-i-avoid-this
+var (
+	DefaultGlobalRate  = Rate{Count: 30, Per: time.Second} //nolint: gochecknoglobals
+	DefaultPrivateRate = Rate{Count: 1, Per: time.Second}  //nolint: gochecknoglobals
+	DefaultGroupRate   = Rate{Count: 20, Per: time.Minute} //nolint: gochecknoglobals
+)
+
+var sendMethods = map[string]struct{}{ //nolint: gochecknoglobals
+	MethodForwardMessage: {},
+	MethodSendAnimation:  {},
+	MethodSendAudio:      {},
+	MethodSendContact:    {},
+	MethodSendDice:       {},
+	MethodSendDocument:   {},
+	MethodSendGame:       {},
+	MethodSendInvoice:    {},
+	MethodSendLocation:   {},
+	MethodSendMediaGroup: {},
+	MethodSendMessage:    {},
+	MethodSendPhoto:      {},
+	MethodSendPoll:       {},
+	MethodSendSticker:    {},
+	MethodSendVenue:      {},
+	MethodSendVideo:      {},
+	MethodSendVideoNote:  {},
+	MethodSendVoice:      {},
+}
+
+// IsSendMethod checks that the method sends a new message and counts in the sending limits.
+func IsSendMethod(method string) bool {
+	_, ok := sendMethods[method]
+
+	return ok
+}
+
+// SetLimiter allow set limiter of outgoing requests. Nil limiter disables limits.
+func (b *Bot) SetLimiter(l Limiter) {
+	if b == nil {
+		b = new(Bot)
+	}
+
+	b.limiter = l
+}
+
+// NewRateLimiter creates a new RateLimiter with provided global limit and limits for each private chat and group
+// (or channel). Use DefaultGlobalRate, DefaultPrivateRate and DefaultGroupRate for limits recommended by Telegram.
+func NewRateLimiter(global, private, group Rate) *RateLimiter {
+	return &RateLimiter{
+		global:  newBucket(global, time.Now()),
+		private: private,
+		group:   group,
+		chats:   make(map[string]*bucket),
+		cleaned: time.Now(),
+	}
+}
+
+// Wait blocks until the request to the method for the chatID is allowed by global and chat buckets or ctx is done.
+// Chats with positive identifiers are limited as private, others (including @usernames of channels) as groups.
+func (l *RateLimiter) Wait(ctx context.Context, method string, chatID string) error {
+	if !IsSendMethod(method) {
+		return nil
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	l.cleanup(now)
+	l.stats.Requests++
+
+	chat, ok := l.chats[chatID]
+	if !ok {
+		rate := l.group
+		if id, err := strconv.ParseInt(chatID, 10, 64); err == nil && id > 0 {
+			rate = l.private
+		}
+
+		chat = newBucket(rate, now)
+		l.chats[chatID] = chat
+	}
+
+	delay := l.global.reserve(now)
+	if chatDelay := chat.reserve(now); chatDelay > delay {
+		delay = chatDelay
+	}
+
+	if delay <= 0 {
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	l.stats.Delayed++
+	l.stats.Waiting++
+	l.stats.WaitTime += delay
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.global.tokens++
+		chat.tokens++
+		l.stats.Waiting--
+		l.mu.Unlock()
+
+		return ctx.Err()
+	case <-timer.C:
+		l.mu.Lock()
+		l.stats.Waiting--
+		l.mu.Unlock()
+
+		return nil
+	}
+}
+
+// Stats returns the current state of limiter.
+func (l *RateLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.global.refill(time.Now())
+
+	result := l.stats
+	result.Chats = len(l.chats)
+	result.GlobalTokens = l.global.tokens
+
+	return result
+}
+
+// cleanup removes buckets of chats which are not used for a while. Must be called under lock.
+func (l *RateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.cleaned) < time.Minute {
+		return
+	}
+
+	l.cleaned = now
+
+	for id, chat := range l.chats {
+		if chat.refill(now); chat.tokens >= float64(chat.rate.Count) {
+			delete(l.chats, id)
+		}
+	}
+}
+
+func newBucket(rate Rate, now time.Time) *bucket {
+	return &bucket{
+		rate:   rate,
+		tokens: float64(rate.Count),
+		last:   now,
+	}
+}
+
+// refill adds tokens accumulated since the last refill.
+func (b *bucket) refill(now time.Time) {
+	if b.rate.Count <= 0 || b.rate.Per <= 0 {
+		return
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * float64(b.rate.Count) / b.rate.Per.Seconds()
+	if b.tokens > float64(b.rate.Count) {
+		b.tokens = float64(b.rate.Count)
+	}
+
+	b.last = now
+}
+
+// reserve takes a token from bucket and returns the time to wait until it will be available.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	if b.rate.Count <= 0 || b.rate.Per <= 0 {
+		return 0
+	}
+
+	b.refill(now)
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens * float64(b.rate.Per) / float64(b.rate.Count))
+}
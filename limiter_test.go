@@ -0,0 +1,51 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(DefaultGlobalRate, Rate{Count: 1, Per: 50 * time.Millisecond}, DefaultGroupRate)
+	ctx := context.Background()
+
+	t.Run("not send", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, l.Wait(ctx, MethodGetMe, ""))
+		}
+	})
+	t.Run("private", func(t *testing.T) {
+		start := time.Now()
+
+		assert.NoError(t, l.Wait(ctx, MethodSendMessage, "42"))
+		assert.NoError(t, l.Wait(ctx, MethodSendMessage, "42"))
+		assert.True(t, time.Since(start) >= 40*time.Millisecond)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		assert.NoError(t, l.Wait(ctx, MethodSendMessage, "24"))
+		assert.Equal(t, context.DeadlineExceeded, l.Wait(ctx, MethodSendMessage, "24"))
+	})
+	t.Run("usernames", func(t *testing.T) {
+		l := NewRateLimiter(DefaultGlobalRate, DefaultPrivateRate, Rate{Count: 1, Per: time.Minute})
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		assert.NoError(t, l.Wait(ctx, MethodSendMessage, "@first"))
+		assert.NoError(t, l.Wait(ctx, MethodSendMessage, "@second"))
+		assert.Equal(t, context.DeadlineExceeded, l.Wait(ctx, MethodSendMessage, "@first"))
+	})
+	t.Run("stats", func(t *testing.T) {
+		stats := l.Stats()
+		assert.Equal(t, uint64(4), stats.Requests)
+		assert.Equal(t, uint64(2), stats.Delayed)
+		assert.Equal(t, 0, stats.Waiting)
+		assert.Equal(t, 2, stats.Chats)
+	})
+}
@@ -1,2 +1,3 @@
-// Package login contains methods for obtaining structure of the user data and its validation.
+// Package login contains methods for obtaining structure of the user data and its validation, and for rendering of
+// Telegram Login Widget and LoginURL buttons.
 package login // import "gitlab.com/toby3d/telegram/login"
@@ -0,0 +1,152 @@
+package login
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"gitlab.com/toby3d/telegram"
+	"golang.org/x/xerrors"
+)
+
+// Options describes both entry points of authorization: the Telegram Login Widget on the website and the LoginURL
+// button in chats. Both of them redirects users to CallbackURL, which must be served by Widget.Handler or
+// Widget.HTTPHandler.
+type Options struct {
+	// Username of the bot linked with the website domain
+	BotUsername string
+
+	// URL of verified callback handler
+	CallbackURL string
+
+	// Name of JavaScript function which is called with user data instead of redirect to CallbackURL, optional for
+	// widget
+	OnAuth string
+
+	// Size of widget button, one of SizeLarge, SizeMedium or SizeSmall, optional
+	Size string
+
+	// Corner radius of widget button from 0 to 20 pixels, optional
+	CornerRadius *int
+
+	// Hide user photo near the widget button
+	HideUserpic bool
+
+	// Request the permission for the bot to send messages to the user
+	RequestAccess bool
+
+	// Language code of widget, optional
+	Lang string
+
+	// New text of the LoginURL button in forwarded messages, optional
+	ForwardText string
+}
+
+// Size represents available sizes of widget button.
+const (
+	SizeLarge  = "large"
+	SizeMedium = "medium"
+	SizeSmall  = "small"
+)
+
+// WidgetScriptURL is the URL of Telegram Login Widget script.
+const WidgetScriptURL = "https://telegram.org/js/telegram-widget.js?22"
+
+// ErrOptions describes options which can not be rendered.
+var ErrOptions = xerrors.New("invalid login options") //nolint: gochecknoglobals
+
+// Script renders the embed code of Telegram Login Widget.
+func (o Options) Script() (string, error) {
+	if err := o.validate(); err != nil {
+		return "", err
+	}
+
+	attrs := [][2]string{{"data-telegram-login", o.BotUsername}}
+
+	if o.Size != "" {
+		attrs = append(attrs, [2]string{"data-size", o.Size})
+	}
+
+	if o.CornerRadius != nil {
+		attrs = append(attrs, [2]string{"data-radius", strconv.Itoa(*o.CornerRadius)})
+	}
+
+	if o.HideUserpic {
+		attrs = append(attrs, [2]string{"data-userpic", "false"})
+	}
+
+	if o.Lang != "" {
+		attrs = append(attrs, [2]string{"data-lang", o.Lang})
+	}
+
+	if o.OnAuth != "" {
+		attrs = append(attrs, [2]string{"data-onauth", o.OnAuth + "(user)"})
+	} else {
+		attrs = append(attrs, [2]string{"data-auth-url", o.CallbackURL})
+	}
+
+	if o.RequestAccess {
+		attrs = append(attrs, [2]string{"data-request-access", "write"})
+	}
+
+	var b strings.Builder
+
+	b.WriteString(`<script async src="` + WidgetScriptURL + `"`)
+
+	for i := range attrs {
+		b.WriteString(" " + attrs[i][0] + `="` + html.EscapeString(attrs[i][1]) + `"`)
+	}
+
+	b.WriteString("></script>")
+
+	return b.String(), nil
+}
+
+// LoginURL returns the LoginURL parameter of inline keyboard button.
+func (o Options) LoginURL() *telegram.LoginURL {
+	return &telegram.LoginURL{
+		URL:                o.CallbackURL,
+		ForwardText:        o.ForwardText,
+		BotUsername:        o.BotUsername,
+		RequestWriteAccess: o.RequestAccess,
+	}
+}
+
+// Button creates a new inline keyboard button which authorizes the user on CallbackURL.
+func (o Options) Button(text string) *telegram.InlineKeyboardButton {
+	return telegram.NewInlineKeyboardButtonLoginURL(text, o.LoginURL())
+}
+
+func (o Options) validate() error {
+	switch {
+	case o.BotUsername == "":
+		return xerrors.Errorf("empty bot username: %w", ErrOptions)
+	case o.OnAuth == "" && o.CallbackURL == "":
+		return xerrors.Errorf("callback URL or onauth function is required: %w", ErrOptions)
+	case o.OnAuth != "" && !isIdentifier(o.OnAuth):
+		return xerrors.Errorf("onauth must be a name of function, got %q: %w", o.OnAuth, ErrOptions)
+	case o.Size != "" && o.Size != SizeLarge && o.Size != SizeMedium && o.Size != SizeSmall:
+		return xerrors.Errorf("unsupported size %q: %w", o.Size, ErrOptions)
+	case o.CornerRadius != nil && (*o.CornerRadius < 0 || *o.CornerRadius > 20):
+		return xerrors.Errorf("corner radius must be from 0 to 20, got %d: %w", *o.CornerRadius, ErrOptions)
+	default:
+		return nil
+	}
+}
+
+// isIdentifier checks that s is a JavaScript identifier or a dot-separated path of identifiers.
+func isIdentifier(s string) bool {
+	for _, name := range strings.Split(s, ".") {
+		if name == "" || (name[0] >= '0' && name[0] <= '9') {
+			return false
+		}
+
+		for _, r := range name {
+			if r != '_' && r != '$' && (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
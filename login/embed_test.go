@@ -0,0 +1,64 @@
+package login
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/toby3d/telegram"
+	"golang.org/x/xerrors"
+)
+
+func TestOptions(t *testing.T) {
+	radius := 0
+	o := Options{
+		BotUsername:   "toby3d_bot",
+		CallbackURL:   "https://toby3d.me/callback?from=widget&x=\"",
+		Size:          SizeMedium,
+		CornerRadius:  &radius,
+		HideUserpic:   true,
+		RequestAccess: true,
+		ForwardText:   "Log in",
+	}
+
+	t.Run("auth url", func(t *testing.T) {
+		script, err := o.Script()
+		assert.NoError(t, err)
+		assert.Equal(t, `<script async src="`+WidgetScriptURL+`" data-telegram-login="toby3d_bot" `+
+			`data-size="medium" data-radius="0" data-userpic="false" `+
+			`data-auth-url="https://toby3d.me/callback?from=widget&amp;x=&#34;" data-request-access="write"></script>`,
+			script)
+	})
+	t.Run("onauth", func(t *testing.T) {
+		o := Options{BotUsername: "toby3d_bot", OnAuth: "app.onTelegramAuth"}
+
+		script, err := o.Script()
+		assert.NoError(t, err)
+		assert.Equal(t, `<script async src="`+WidgetScriptURL+`" data-telegram-login="toby3d_bot" `+
+			`data-onauth="app.onTelegramAuth(user)"></script>`, script)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		big := 21
+
+		for name, o := range map[string]Options{
+			"username": {CallbackURL: "https://toby3d.me/callback"},
+			"callback": {BotUsername: "toby3d_bot"},
+			"onauth":   {BotUsername: "toby3d_bot", OnAuth: "alert(1);x"},
+			"size":     {BotUsername: "toby3d_bot", OnAuth: "auth", Size: "huge"},
+			"radius":   {BotUsername: "toby3d_bot", OnAuth: "auth", CornerRadius: &big},
+		} {
+			_, err := o.Script()
+			assert.True(t, xerrors.Is(err, ErrOptions), name)
+		}
+	})
+	t.Run("button", func(t *testing.T) {
+		assert.Equal(t, &telegram.InlineKeyboardButton{
+			Text: "Log in",
+			LoginURL: &telegram.LoginURL{
+				URL:                o.CallbackURL,
+				ForwardText:        "Log in",
+				BotUsername:        "toby3d_bot",
+				RequestWriteAccess: true,
+			},
+		}, o.Button("Log in"))
+	})
+}
@@ -1,44 +1,51 @@
 package login_test
 
-/*
 import (
 	"log"
 
-	httprouter "github.com/buaazp/fasthttprouter"
 	http "github.com/valyala/fasthttp"
 	"gitlab.com/toby3d/telegram/login"
 )
 
 func Example_fastStart() {
 	// We use bot AccessToken from @BotFather or telegram.Bot structure
-	botAccessToken := "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11"
+	w := login.NewWidget("123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11")
 
-	// Create example server with example callback handler
-	r := httprouter.New()
-	r.GET("/callback", func(ctx *http.RequestCtx) {
+	// Create example callback handler which is called only for verified users
+	callback := w.Handler(func(ctx *http.RequestCtx) {
 		defer ctx.SetConnectionClose()
 
-		// You not need decode data to User structure if you want only
-		// validate it
-		u, err := login.ParseUser(ctx.QueryArgs())
-		if err != nil {
-			ctx.Error("bad request", http.StatusBadRequest)
-			return
-		}
-
-		// Check User structure
-		ok, err := login.CheckAuthorization(u, botAccessToken)
-		if err != nil || !ok { // NANI!? It's a invalid data!
-			ctx.Error("bad request", http.StatusBadRequest)
-			return
-		}
+		u, _ := login.FromContext(ctx)
 
 		// All is ok! Hello, human!
 		ctx.Success("text/html", []byte("hello, "+u.FullName()+"!"))
 	})
 
-	if err := http.ListenAndServe(":8000", r.Handler); err != nil {
+	if err := http.ListenAndServe(":8000", func(ctx *http.RequestCtx) {
+		switch string(ctx.Path()) {
+		case "/callback":
+			callback(ctx)
+		default:
+			ctx.NotFound()
+		}
+	}); err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+func ExampleParseUser() {
+	args := http.AcquireArgs()
+	defer http.ReleaseArgs(args)
+
+	args.Parse("id=123&first_name=Maxim&auth_date=1546300800&hash=abc")
+
+	u, err := login.ParseUser(args)
+	if err != nil {
 		log.Fatalln(err.Error())
 	}
+
+	// You need check User structure before use
+	if err = login.NewWidget("123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11").Verify(*u); err != nil {
+		log.Println(err.Error())
+	}
 }
-*/
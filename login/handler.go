@@ -0,0 +1,69 @@
+package login
+
+import (
+	"context"
+	nethttp "net/http"
+
+	http "github.com/valyala/fasthttp"
+)
+
+// contextKey is the key of verified User in context.Context of net/http requests.
+type contextKey struct{}
+
+// UserValueKey is the key of verified User in user values of fasthttp.RequestCtx.
+const UserValueKey = "telegram_login_user"
+
+// Handler returns fasthttp callback handler which verifies user data from query arguments and calls next with User
+// saved in user values. Requests with invalid data are rejected with 400 or 403 status codes.
+func (w Widget) Handler(next http.RequestHandler) http.RequestHandler {
+	return func(ctx *http.RequestCtx) {
+		u, err := ParseUser(ctx.QueryArgs())
+		if err != nil {
+			ctx.Error(err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err = w.Verify(*u); err != nil {
+			ctx.Error(err.Error(), http.StatusForbidden)
+			return
+		}
+
+		ctx.SetUserValue(UserValueKey, u)
+		next(ctx)
+	}
+}
+
+// HTTPHandler returns net/http callback handler which verifies user data from query arguments and calls next with
+// User saved in request context. Requests with invalid data are rejected with 400 or 403 status codes.
+func (w Widget) HTTPHandler(next nethttp.Handler) nethttp.Handler {
+	return nethttp.HandlerFunc(func(rw nethttp.ResponseWriter, r *nethttp.Request) {
+		u, err := ParseValues(r.URL.Query())
+		if err != nil {
+			nethttp.Error(rw, err.Error(), nethttp.StatusBadRequest)
+			return
+		}
+
+		if err = w.Verify(*u); err != nil {
+			nethttp.Error(rw, err.Error(), nethttp.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(rw, r.WithContext(NewContext(r.Context(), u)))
+	})
+}
+
+// NewContext returns a new context with the User.
+func NewContext(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// FromContext returns the verified User from request context of HTTPHandler or fasthttp.RequestCtx of Handler.
+func FromContext(ctx context.Context) (*User, bool) {
+	if u, ok := ctx.Value(contextKey{}).(*User); ok {
+		return u, true
+	}
+
+	u, ok := ctx.Value(UserValueKey).(*User)
+
+	return u, ok
+}
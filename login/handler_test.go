@@ -0,0 +1,76 @@
+package login
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+)
+
+func TestWidgetHandler(t *testing.T) {
+	w := NewWidget("hackme")
+	u := User{ID: 123, FirstName: "Maxim", AuthDate: time.Now().Unix()}
+	u.Hash, _ = w.GenerateHash(u)
+
+	v := make(url.Values)
+	v.Set(KeyID, strconv.Itoa(u.ID))
+	v.Set(KeyFirstName, u.FirstName)
+	v.Set(KeyAuthDate, strconv.FormatInt(u.AuthDate, 10))
+	v.Set(KeyHash, u.Hash)
+	valid := "/callback?" + v.Encode()
+
+	v.Set(KeyFirstName, "Max")
+	forged := "/callback?" + v.Encode()
+
+	t.Run("fasthttp", func(t *testing.T) {
+		h := w.Handler(func(ctx *http.RequestCtx) {
+			user, ok := FromContext(ctx)
+			if assert.True(t, ok) {
+				ctx.SetBodyString(user.FullName())
+			}
+		})
+
+		for uri, status := range map[string]int{
+			valid:                      http.StatusOK,
+			forged:                     http.StatusForbidden,
+			"/callback?first_name=Max": http.StatusBadRequest,
+		} {
+			var ctx http.RequestCtx
+			ctx.Request.SetRequestURI(uri)
+
+			h(&ctx)
+			assert.Equal(t, status, ctx.Response.StatusCode(), uri)
+
+			if status == http.StatusOK {
+				assert.Equal(t, "Maxim", string(ctx.Response.Body()))
+			}
+		}
+	})
+	t.Run("net/http", func(t *testing.T) {
+		h := w.HTTPHandler(nethttp.HandlerFunc(func(rw nethttp.ResponseWriter, r *nethttp.Request) {
+			user, ok := FromContext(r.Context())
+			if assert.True(t, ok) {
+				_, _ = rw.Write([]byte(user.FullName()))
+			}
+		}))
+
+		for uri, status := range map[string]int{
+			valid:                      nethttp.StatusOK,
+			forged:                     nethttp.StatusForbidden,
+			"/callback?first_name=Max": nethttp.StatusBadRequest,
+		} {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, uri, nil))
+			assert.Equal(t, status, rec.Code, uri)
+
+			if status == nethttp.StatusOK {
+				assert.Equal(t, "Maxim", rec.Body.String())
+			}
+		}
+	})
+}
@@ -4,13 +4,21 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
 )
 
 type (
+	// Widget verifies users authorized by Telegram Login Widget for the bot.
 	Widget struct {
 		accessToken string
+		maxAge      time.Duration
 	}
 
 	// User contains data about authenticated user.
@@ -36,8 +44,61 @@ const (
 	KeyUsername  = "username"
 )
 
+// DefaultMaxAge is the default maximum age of authorization data accepted by Widget.
+const DefaultMaxAge time.Duration = 24 * time.Hour
+
+// Verification errors.
+var (
+	ErrUserData = xerrors.New("invalid user data")             //nolint: gochecknoglobals
+	ErrHash     = xerrors.New("hash of user data is invalid")  //nolint: gochecknoglobals
+	ErrAuthDate = xerrors.New("user authorization is too old") //nolint: gochecknoglobals
+)
+
+// NewWidget creates a new Widget for the bot access token with DefaultMaxAge.
 func NewWidget(accessToken string) *Widget {
-	return &Widget{accessToken: accessToken}
+	return &Widget{accessToken: accessToken, maxAge: DefaultMaxAge}
+}
+
+// SetMaxAge sets the maximum age of AuthDate for blocking of replayed authorization links. Zero disables the check.
+func (w *Widget) SetMaxAge(maxAge time.Duration) {
+	w.maxAge = maxAge
+}
+
+// ParseUser parses user data from query arguments of callback request.
+func ParseUser(args *http.Args) (*User, error) {
+	v := make(url.Values)
+	args.VisitAll(func(key, value []byte) { v.Add(string(key), string(value)) })
+
+	return ParseValues(v)
+}
+
+// ParseValues parses user data from query values of callback request.
+func ParseValues(v url.Values) (*User, error) {
+	id, err := strconv.Atoi(v.Get(KeyID))
+	if err != nil {
+		return nil, xerrors.Errorf("%s: %w", KeyID, ErrUserData)
+	}
+
+	authDate, err := strconv.ParseInt(v.Get(KeyAuthDate), 10, 64)
+	if err != nil {
+		return nil, xerrors.Errorf("%s: %w", KeyAuthDate, ErrUserData)
+	}
+
+	u := User{
+		ID:        id,
+		AuthDate:  authDate,
+		FirstName: v.Get(KeyFirstName),
+		Hash:      v.Get(KeyHash),
+		LastName:  v.Get(KeyLastName),
+		PhotoURL:  v.Get(KeyPhotoURL),
+		Username:  v.Get(KeyUsername),
+	}
+
+	if u.Hash == "" {
+		return nil, xerrors.Errorf("%s: %w", KeyHash, ErrUserData)
+	}
+
+	return &u, nil
 }
 
 // CheckAuthorization verify the authentication and the integrity of the data
@@ -45,37 +106,70 @@ func NewWidget(accessToken string) *Widget {
 // representation of the HMAC-SHA-256 signature of the data-check-string with the
 // SHA256 hash of the bot's token used as a secret key.
 func (w Widget) CheckAuthorization(u User) (bool, error) {
-	hash, err := w.GenerateHash(u)
-	return hash == u.Hash, err
+	switch err := w.Verify(u); {
+	case err == nil:
+		return true, nil
+	case xerrors.Is(err, ErrHash), xerrors.Is(err, ErrAuthDate):
+		return false, nil
+	default:
+		return false, err
+	}
 }
 
+// Verify checks the hash of user data in constant time and that AuthDate is not older than max age. It returns
+// ErrHash or ErrAuthDate if the check fails.
+func (w Widget) Verify(u User) error {
+	hash, err := hex.DecodeString(u.Hash)
+	if err != nil {
+		return ErrHash
+	}
+
+	if !hmac.Equal(hash, w.sign(u)) {
+		return ErrHash
+	}
+
+	if w.maxAge <= 0 {
+		return nil
+	}
+
+	if age := time.Since(u.AuthTime()); age > w.maxAge || age < -time.Minute {
+		return ErrAuthDate
+	}
+
+	return nil
+}
+
+// GenerateHash returns the hexadecimal representation of the HMAC-SHA-256 signature of user data.
 func (w Widget) GenerateHash(u User) (string, error) {
-	a := http.AcquireArgs()
-	defer http.ReleaseArgs(a)
+	return hex.EncodeToString(w.sign(u)), nil
+}
 
-	// WARN: do not change order of this args, it must be alphabetical
-	a.SetUint(KeyAuthDate, int(u.AuthDate))
-	a.Set(KeyFirstName, u.FirstName)
-	a.SetUint(KeyID, u.ID)
+// sign returns the HMAC-SHA-256 signature of the data-check-string of user data: all fields sorted alphabetically
+// in format key=<value> and separated by a line feed.
+func (w Widget) sign(u User) []byte {
+	fields := []string{
+		KeyAuthDate + "=" + strconv.FormatInt(u.AuthDate, 10),
+		KeyFirstName + "=" + u.FirstName,
+		KeyID + "=" + strconv.Itoa(u.ID),
+	}
 
 	if u.LastName != "" {
-		a.Set(KeyLastName, u.LastName)
+		fields = append(fields, KeyLastName+"="+u.LastName)
 	}
 
 	if u.PhotoURL != "" {
-		a.Set(KeyPhotoURL, u.PhotoURL)
+		fields = append(fields, KeyPhotoURL+"="+u.PhotoURL)
 	}
 
 	if u.Username != "" {
-		a.Set(KeyUsername, u.Username)
+		fields = append(fields, KeyUsername+"="+u.Username)
 	}
 
+	sort.Strings(fields)
+
 	secretKey := sha256.Sum256([]byte(w.accessToken))
 	h := hmac.New(sha256.New, secretKey[0:])
+	_, _ = h.Write([]byte(strings.Join(fields, "\n")))
 
-	if _, err := h.Write(a.QueryString()); err != nil {
-		return "", err
-	}
-
-	return hex.EncodeToString(h.Sum(nil)), nil
+	return h.Sum(nil)
 }
@@ -1,10 +1,17 @@
 package login
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
 )
 
 func TestNew(t *testing.T) {
@@ -52,3 +59,87 @@ func TestCheckAuthorization(t *testing.T) {
 		assert.True(t, ok)
 	})
 }
+
+func TestWidgetVerify(t *testing.T) {
+	w := NewWidget("hackme")
+	u := User{
+		ID:        123,
+		Username:  "toby3d",
+		FirstName: "Maxim",
+		PhotoURL:  "https://toby3d.me/avatar.jpg?size=big",
+		AuthDate:  time.Now().UTC().Unix(),
+	}
+
+	secretKey := sha256.Sum256([]byte("hackme"))
+	h := hmac.New(sha256.New, secretKey[:])
+	_, _ = h.Write([]byte("auth_date=" + strconv.FormatInt(u.AuthDate, 10) + "\nfirst_name=Maxim\nid=123\n" +
+		"photo_url=https://toby3d.me/avatar.jpg?size=big\nusername=toby3d"))
+	u.Hash = hex.EncodeToString(h.Sum(nil))
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, w.Verify(u))
+	})
+	t.Run("hash", func(t *testing.T) {
+		invalid := u
+		invalid.FirstName = "Max"
+		assert.Equal(t, ErrHash, w.Verify(invalid))
+
+		invalid = u
+		invalid.Hash = "wtf"
+		assert.Equal(t, ErrHash, w.Verify(invalid))
+	})
+	t.Run("max age", func(t *testing.T) {
+		old := u
+		old.AuthDate = time.Now().Add(-2 * DefaultMaxAge).Unix()
+		old.Hash, _ = w.GenerateHash(old)
+		assert.Equal(t, ErrAuthDate, w.Verify(old))
+
+		ok, err := w.CheckAuthorization(old)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		w := NewWidget("hackme")
+		w.SetMaxAge(0)
+		assert.NoError(t, w.Verify(old))
+	})
+}
+
+func TestParseUser(t *testing.T) {
+	query := "id=123&first_name=Maxim&username=toby3d&photo_url=https%3A%2F%2Ftoby3d.me%2Favatar.jpg&" +
+		"auth_date=1546300800&hash=abc"
+	expected := &User{
+		ID:        123,
+		FirstName: "Maxim",
+		Username:  "toby3d",
+		PhotoURL:  "https://toby3d.me/avatar.jpg",
+		AuthDate:  1546300800,
+		Hash:      "abc",
+	}
+
+	t.Run("args", func(t *testing.T) {
+		args := http.AcquireArgs()
+		defer http.ReleaseArgs(args)
+		args.Parse(query)
+
+		u, err := ParseUser(args)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, u)
+	})
+	t.Run("values", func(t *testing.T) {
+		v, err := url.ParseQuery(query)
+		assert.NoError(t, err)
+
+		u, err := ParseValues(v)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, u)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, query := range []string{"first_name=Maxim&auth_date=1&hash=abc", "id=1&hash=abc", "id=1&auth_date=1"} {
+			v, err := url.ParseQuery(query)
+			assert.NoError(t, err)
+
+			_, err = ParseValues(v)
+			assert.True(t, xerrors.Is(err, ErrUserData), query)
+		}
+	})
+}
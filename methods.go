@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"context"
 	"strconv"
 	"strings"
 )
@@ -698,13 +699,13 @@ type (
 
 // GetMe testing your bot's auth token. Returns basic information about the bot in form of a User object.
 func (b Bot) GetMe() (*User, error) {
-	src, err := b.Do(MethodGetMe, nil)
-	if err != nil {
-		return nil, err
-	}
+	return b.GetMeContext(context.Background())
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetMeContext is the same as GetMe, but with a context.Context.
+func (b Bot) GetMeContext(ctx context.Context) (*User, error) {
+	resp, err := b.DoContext(ctx, MethodGetMe, nil)
+	if err != nil {
 		return nil, err
 	}
 
@@ -725,13 +726,13 @@ func NewMessage(chatID int64, text string) SendMessage {
 
 // SendMessage send text messages. On success, the sent Message is returned.
 func (b Bot) SendMessage(p SendMessage) (*Message, error) {
-	src, err := b.Do(MethodSendMessage, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SendMessageContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendMessageContext is the same as SendMessage, but with a context.Context.
+func (b Bot) SendMessageContext(ctx context.Context, p SendMessage) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSendMessage, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -753,13 +754,13 @@ func NewForward(fromChatID, toChatID int64, messageID int) ForwardMessage {
 
 // ForwardMessage forward messages of any kind. On success, the sent Message is returned.
 func (b Bot) ForwardMessage(p ForwardMessage) (*Message, error) {
-	src, err := b.Do(MethodForwardMessage, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.ForwardMessageContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// ForwardMessageContext is the same as ForwardMessage, but with a context.Context.
+func (b Bot) ForwardMessageContext(ctx context.Context, p ForwardMessage) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodForwardMessage, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -780,6 +781,24 @@ func NewPhoto(chatID int64, photo *InputFile) SendPhoto {
 
 // SendPhoto send photos. On success, the sent Message is returned.
 func (b Bot) SendPhoto(p SendPhoto) (*Message, error) {
+	return b.SendPhotoContext(context.Background(), p)
+}
+
+// SendPhotoContext is the same as SendPhoto, but with a context.Context. Uploaded photo is replaced by cached file
+// identifier if Bot has upload cache.
+func (b Bot) SendPhotoContext(ctx context.Context, p SendPhoto) (*Message, error) {
+	send := func() (*Message, error) { return b.sendPhoto(ctx, p) }
+
+	return b.cacheUpload(ctx, &p.Photo, send, func(m *Message) string {
+		if ps := m.Photo.Largest(); ps != nil {
+			return ps.FileID
+		}
+
+		return ""
+	})
+}
+
+func (b Bot) sendPhoto(ctx context.Context, p SendPhoto) (*Message, error) {
 	params := make(map[string]string)
 	params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
 	params["caption"] = p.Caption
@@ -789,29 +808,16 @@ func (b Bot) SendPhoto(p SendPhoto) (*Message, error) {
 	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
 
 	var err error
-	if params["photo"], err = b.marshler.MarshalToString(p.Photo); err != nil {
-		return nil, err
-	}
-
 	if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
 		return nil, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.Photo.IsAttachment() {
-		files = append(files, p.Photo)
-	}
-
-	src, err := b.Upload(MethodSendPhoto, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"photo": p.Photo})
+	resp, err := b.UploadContext(ctx, MethodSendPhoto, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := new(Message)
 	if err = b.marshler.Unmarshal(resp.Result, result); err != nil {
 		return nil, err
@@ -831,6 +837,11 @@ func NewAudio(chatID int64, audio *InputFile) SendAudio {
 //
 // For sending voice messages, use the sendVoice method instead.
 func (b Bot) SendAudio(p SendAudio) (*Message, error) {
+	return b.SendAudioContext(context.Background(), p)
+}
+
+// SendAudioContext is the same as SendAudio, but with a context.Context.
+func (b Bot) SendAudioContext(ctx context.Context, p SendAudio) (*Message, error) {
 	params := make(map[string]string)
 	params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
 	params["caption"] = p.Caption
@@ -842,37 +853,16 @@ func (b Bot) SendAudio(p SendAudio) (*Message, error) {
 	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
 
 	var err error
-	if params["audio"], err = b.marshler.MarshalToString(p.Audio); err != nil {
-		return nil, err
-	}
-
-	if params["thumb"], err = b.marshler.MarshalToString(p.Thumb); err != nil {
-		return nil, err
-	}
-
 	if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
 		return nil, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.Audio.IsAttachment() {
-		files = append(files, p.Audio)
-	}
-
-	if p.Thumb.IsAttachment() {
-		files = append(files, p.Thumb)
-	}
-
-	src, err := b.Upload(MethodSendAudio, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"audio": p.Audio, "thumb": p.Thumb})
+	resp, err := b.UploadContext(ctx, MethodSendAudio, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := new(Message)
 	if err = b.marshler.Unmarshal(resp.Result, &result); err != nil {
 		return nil, err
@@ -890,6 +880,24 @@ func NewDocument(chatID int64, document *InputFile) SendDocument {
 
 // SendDocument send general files. On success, the sent Message is returned. Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
 func (b Bot) SendDocument(p SendDocument) (*Message, error) {
+	return b.SendDocumentContext(context.Background(), p)
+}
+
+// SendDocumentContext is the same as SendDocument, but with a context.Context. Uploaded document is replaced by cached file
+// identifier if Bot has upload cache.
+func (b Bot) SendDocumentContext(ctx context.Context, p SendDocument) (*Message, error) {
+	send := func() (*Message, error) { return b.sendDocument(ctx, p) }
+
+	return b.cacheUpload(ctx, &p.Document, send, func(m *Message) string {
+		if m.Document == nil {
+			return ""
+		}
+
+		return m.Document.FileID
+	})
+}
+
+func (b Bot) sendDocument(ctx context.Context, p SendDocument) (*Message, error) {
 	params := make(map[string]string)
 	params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
 	params["caption"] = p.Caption
@@ -898,29 +906,16 @@ func (b Bot) SendDocument(p SendDocument) (*Message, error) {
 	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
 
 	var err error
-	if params["document"], err = b.marshler.MarshalToString(p.Document); err != nil {
-		return nil, err
-	}
-
 	if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
 		return nil, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.Document.IsAttachment() {
-		files = append(files, p.Document)
-	}
-
-	src, err := b.Upload(MethodSendDocument, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"document": p.Document})
+	resp, err := b.UploadContext(ctx, MethodSendDocument, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := new(Message)
 	if err = b.marshler.Unmarshal(resp.Result, result); err != nil {
 		return nil, err
@@ -938,6 +933,24 @@ func NewVideo(chatID int64, video *InputFile) SendVideo {
 
 // SendVideo send video files, Telegram clients support mp4 videos (other formats may be sent as Document). On success, the sent Message is returned. Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
 func (b Bot) SendVideo(p SendVideo) (*Message, error) {
+	return b.SendVideoContext(context.Background(), p)
+}
+
+// SendVideoContext is the same as SendVideo, but with a context.Context. Uploaded video is replaced by cached file
+// identifier if Bot has upload cache.
+func (b Bot) SendVideoContext(ctx context.Context, p SendVideo) (*Message, error) {
+	send := func() (*Message, error) { return b.sendVideo(ctx, p) }
+
+	return b.cacheUpload(ctx, &p.Video, send, func(m *Message) string {
+		if m.Video == nil {
+			return ""
+		}
+
+		return m.Video.FileID
+	})
+}
+
+func (b Bot) sendVideo(ctx context.Context, p SendVideo) (*Message, error) {
 	params := make(map[string]string)
 	params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
 	params["duration"] = strconv.Itoa(p.Duration)
@@ -950,37 +963,16 @@ func (b Bot) SendVideo(p SendVideo) (*Message, error) {
 	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
 
 	var err error
-	if params["video"], err = b.marshler.MarshalToString(p.Video); err != nil {
-		return nil, err
-	}
-
-	if params["thumb"], err = b.marshler.MarshalToString(p.Thumb); err != nil {
-		return nil, err
-	}
-
 	if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
 		return nil, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.Video.IsAttachment() {
-		files = append(files, p.Video)
-	}
-
-	if p.Thumb.IsAttachment() {
-		files = append(files, p.Thumb)
-	}
-
-	src, err := b.Upload(MethodSendVideo, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"video": p.Video, "thumb": p.Thumb})
+	resp, err := b.UploadContext(ctx, MethodSendVideo, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := new(Message)
 	if err = b.marshler.Unmarshal(resp.Result, result); err != nil {
 		return nil, err
@@ -998,6 +990,11 @@ func NewAnimation(chatID int64, animation *InputFile) SendAnimation {
 
 // SendAnimation send animation files (GIF or H.264/MPEG-4 AVC video without sound). On success, the sent Message is returned. Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
 func (b Bot) SendAnimation(p SendAnimation) (*Message, error) {
+	return b.SendAnimationContext(context.Background(), p)
+}
+
+// SendAnimationContext is the same as SendAnimation, but with a context.Context.
+func (b Bot) SendAnimationContext(ctx context.Context, p SendAnimation) (*Message, error) {
 	params := make(map[string]string)
 	params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
 	params["duration"] = strconv.Itoa(p.Duration)
@@ -1009,37 +1006,16 @@ func (b Bot) SendAnimation(p SendAnimation) (*Message, error) {
 	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
 
 	var err error
-	if params["animation"], err = b.marshler.MarshalToString(p.Animation); err != nil {
-		return nil, err
-	}
-
-	if params["thumb"], err = b.marshler.MarshalToString(p.Thumb); err != nil {
-		return nil, err
-	}
-
 	if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
 		return nil, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.Animation.IsAttachment() {
-		files = append(files, p.Animation)
-	}
-
-	if p.Thumb.IsAttachment() {
-		files = append(files, p.Thumb)
-	}
-
-	src, err := b.Upload(MethodSendAnimation, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"animation": p.Animation, "thumb": p.Thumb})
+	resp, err := b.UploadContext(ctx, MethodSendAnimation, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := new(Message)
 	if err = b.marshler.Unmarshal(resp.Result, result); err != nil {
 		return nil, err
@@ -1057,6 +1033,11 @@ func NewVoice(chatID int64, voice *InputFile) SendVoice {
 
 // SendVoice send audio files, if you want Telegram clients to display the file as a playable voice message. For this to work, your audio must be in an .ogg file encoded with OPUS (other formats may be sent as Audio or Document). On success, the sent Message is returned. Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
 func (b Bot) SendVoice(p SendVoice) (*Message, error) {
+	return b.SendVoiceContext(context.Background(), p)
+}
+
+// SendVoiceContext is the same as SendVoice, but with a context.Context.
+func (b Bot) SendVoiceContext(ctx context.Context, p SendVoice) (*Message, error) {
 	params := make(map[string]string)
 	params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
 	params["duration"] = strconv.Itoa(p.Duration)
@@ -1066,29 +1047,16 @@ func (b Bot) SendVoice(p SendVoice) (*Message, error) {
 	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
 
 	var err error
-	if params["voice"], err = b.marshler.MarshalToString(p.Voice); err != nil {
-		return nil, err
-	}
-
 	if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
 		return nil, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.Voice.IsAttachment() {
-		files = append(files, p.Voice)
-	}
-
-	src, err := b.Upload(MethodSendVoice, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"voice": p.Voice})
+	resp, err := b.UploadContext(ctx, MethodSendVoice, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := new(Message)
 	if err = b.marshler.Unmarshal(resp.Result, result); err != nil {
 		return nil, err
@@ -1106,6 +1074,11 @@ func NewVideoNote(chatID int64, videoNote *InputFile) SendVideoNote {
 
 // SendVideoNote send video messages. On success, the sent Message is returned.
 func (b Bot) SendVideoNote(p SendVideoNote) (*Message, error) {
+	return b.SendVideoNoteContext(context.Background(), p)
+}
+
+// SendVideoNoteContext is the same as SendVideoNote, but with a context.Context.
+func (b Bot) SendVideoNoteContext(ctx context.Context, p SendVideoNote) (*Message, error) {
 	params := make(map[string]string)
 	params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
 	params["duration"] = strconv.Itoa(p.Duration)
@@ -1114,37 +1087,16 @@ func (b Bot) SendVideoNote(p SendVideoNote) (*Message, error) {
 	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
 
 	var err error
-	if params["video_note"], err = b.marshler.MarshalToString(p.VideoNote); err != nil {
-		return nil, err
-	}
-
-	if params["thumb"], err = b.marshler.MarshalToString(p.Thumb); err != nil {
-		return nil, err
-	}
-
 	if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
 		return nil, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.VideoNote.IsAttachment() {
-		files = append(files, p.VideoNote)
-	}
-
-	if p.Thumb.IsAttachment() {
-		files = append(files, p.Thumb)
-	}
-
-	src, err := b.Upload(MethodSendVideoNote, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"video_note": p.VideoNote, "thumb": p.Thumb})
+	resp, err := b.UploadContext(ctx, MethodSendVideoNote, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := new(Message)
 	if err = b.marshler.Unmarshal(resp.Result, result); err != nil {
 		return nil, err
@@ -1162,22 +1114,22 @@ func NewMediaGroup(chatID int64, media ...AlbumMedia) SendMediaGroup {
 
 // SendMediaGroup send a group of photos or videos as an album. On success, an array of the sent Messages is returned.
 func (b Bot) SendMediaGroup(p SendMediaGroup) ([]*Message, error) {
-	media := make([]string, len(p.Media), 10)
-	files := make([]*InputFile, 0)
+	return b.SendMediaGroupContext(context.Background(), p)
+}
 
-	for i := range p.Media {
-		m := p.Media[i].GetMedia()
+// SendMediaGroupContext is the same as SendMediaGroup, but with a context.Context.
+func (b Bot) SendMediaGroupContext(ctx context.Context, p SendMediaGroup) ([]*Message, error) {
+	media := make([]string, 0, len(p.Media))
+	parts := make([]UploadPart, 0)
 
-		if m.IsAttachment() {
-			files = append(files, m)
-		}
-
-		src, err := b.marshler.MarshalToString(p.Media[i])
+	for i := range p.Media {
+		src, newParts, err := b.attachMedia(p.Media[i], parts)
 		if err != nil {
 			return nil, err
 		}
 
-		media = append(media, src)
+		media = append(media, string(src))
+		parts = newParts
 	}
 
 	params := make(map[string]string)
@@ -1186,16 +1138,11 @@ func (b Bot) SendMediaGroup(p SendMediaGroup) ([]*Message, error) {
 	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
 	params["media"] = "[" + strings.Join(media, ",") + "]"
 
-	src, err := b.Upload(MethodSendMediaGroup, params, files...)
+	resp, err := b.UploadContext(ctx, MethodSendMediaGroup, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := make([]*Message, 0)
 	if err = b.marshler.Unmarshal(resp.Result, &result); err != nil {
 		return nil, err
@@ -1214,13 +1161,13 @@ func NewLocation(chatID int64, latitude, longitude float32) SendLocation {
 
 // SendLocation send point on the map. On success, the sent Message is returned.
 func (b Bot) SendLocation(p SendLocation) (*Message, error) {
-	src, err := b.Do(MethodSendLocation, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SendLocationContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendLocationContext is the same as SendLocation, but with a context.Context.
+func (b Bot) SendLocationContext(ctx context.Context, p SendLocation) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSendLocation, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -1241,13 +1188,13 @@ func NewLiveLocation(latitude, longitude float32) EditMessageLiveLocation {
 
 // EditMessageLiveLocation edit live location messages. A location can be edited until its live_period expires or editing is explicitly disabled by a call to stopMessageLiveLocation. On success, if the edited message was sent by the bot, the edited Message is returned, otherwise True is returned.
 func (b Bot) EditMessageLiveLocation(p EditMessageLiveLocation) (*Message, bool, error) {
-	src, err := b.Do(MethodEditMessageLiveLocation, p)
-	if err != nil {
-		return nil, false, err
-	}
+	return b.EditMessageLiveLocationContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// EditMessageLiveLocationContext is the same as EditMessageLiveLocation, but with a context.Context.
+func (b Bot) EditMessageLiveLocationContext(ctx context.Context, p EditMessageLiveLocation) (*Message, bool, error) {
+	resp, err := b.DoContext(ctx, MethodEditMessageLiveLocation, p)
+	if err != nil {
 		return nil, false, err
 	}
 
@@ -1261,13 +1208,13 @@ func (b Bot) EditMessageLiveLocation(p EditMessageLiveLocation) (*Message, bool,
 
 // StopMessageLiveLocation stop updating a live location message before live_period expires. On success, if the message was sent by the bot, the sent Message is returned, otherwise True is returned.
 func (b Bot) StopMessageLiveLocation(p StopMessageLiveLocation) (*Message, bool, error) {
-	src, err := b.Do(MethodStopMessageLiveLocation, p)
-	if err != nil {
-		return nil, false, err
-	}
+	return b.StopMessageLiveLocationContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// StopMessageLiveLocationContext is the same as StopMessageLiveLocation, but with a context.Context.
+func (b Bot) StopMessageLiveLocationContext(ctx context.Context, p StopMessageLiveLocation) (*Message, bool, error) {
+	resp, err := b.DoContext(ctx, MethodStopMessageLiveLocation, p)
+	if err != nil {
 		return nil, false, err
 	}
 
@@ -1291,13 +1238,13 @@ func NewVenue(chatID int64, latitude, longitude float32, title, address string)
 
 // SendVenue send information about a venue. On success, the sent Message is returned.
 func (b Bot) SendVenue(p SendVenue) (*Message, error) {
-	src, err := b.Do(MethodSendVenue, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SendVenueContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendVenueContext is the same as SendVenue, but with a context.Context.
+func (b Bot) SendVenueContext(ctx context.Context, p SendVenue) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSendVenue, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -1319,13 +1266,13 @@ func NewContact(chatID int64, phoneNumber, firstName string) SendContact {
 
 // SendContact send phone contacts. On success, the sent Message is returned.
 func (b Bot) SendContact(p SendContact) (*Message, error) {
-	src, err := b.Do(MethodSendContact, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SendContactContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendContactContext is the same as SendContact, but with a context.Context.
+func (b Bot) SendContactContext(ctx context.Context, p SendContact) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSendContact, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -1347,13 +1294,13 @@ func NewPoll(chatID int64, question string, options ...string) SendPoll {
 
 // SendPoll send a native poll. A native poll can't be sent to a private chat. On success, the sent Message is returned.
 func (b Bot) SendPoll(p SendPoll) (*Message, error) {
-	src, err := b.Do(MethodSendPoll, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SendPollContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendPollContext is the same as SendPoll, but with a context.Context.
+func (b Bot) SendPollContext(ctx context.Context, p SendPoll) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSendPoll, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -1369,13 +1316,13 @@ func (b Bot) SendPoll(p SendPoll) (*Message, error) {
 // we're aware of the “proper” singular of die. But it's awkward, and we decided to help it change. One dice at a
 // time!)
 func (b Bot) SendDice(p SendDice) (*Message, error) {
-	src, err := b.Do(MethodSendDice, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SendDiceContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendDiceContext is the same as SendDice, but with a context.Context.
+func (b Bot) SendDiceContext(ctx context.Context, p SendDice) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSendDice, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -1391,13 +1338,13 @@ func (b Bot) SendDice(p SendDice) (*Message, error) {
 //
 // We only recommend using this method when a response from the bot will take a noticeable amount of time to arrive.
 func (b Bot) SendChatAction(cid int64, action string) (bool, error) {
-	src, err := b.Do(MethodSendChatAction, SendChatAction{ChatID: cid, Action: action})
-	if err != nil {
-		return false, err
-	}
+	return b.SendChatActionContext(context.Background(), cid, action)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendChatActionContext is the same as SendChatAction, but with a context.Context.
+func (b Bot) SendChatActionContext(ctx context.Context, cid int64, action string) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSendChatAction, SendChatAction{ChatID: cid, Action: action})
+	if err != nil {
 		return false, err
 	}
 
@@ -1411,13 +1358,13 @@ func (b Bot) SendChatAction(cid int64, action string) (bool, error) {
 
 // GetUserProfilePhotos get a list of profile pictures for a user. Returns a UserProfilePhotos object.
 func (b Bot) GetUserProfilePhotos(p GetUserProfilePhotos) (*UserProfilePhotos, error) {
-	src, err := b.Do(MethodGetUserProfilePhotos, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.GetUserProfilePhotosContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetUserProfilePhotosContext is the same as GetUserProfilePhotos, but with a context.Context.
+func (b Bot) GetUserProfilePhotosContext(ctx context.Context, p GetUserProfilePhotos) (*UserProfilePhotos, error) {
+	resp, err := b.DoContext(ctx, MethodGetUserProfilePhotos, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -1432,14 +1379,16 @@ func (b Bot) GetUserProfilePhotos(p GetUserProfilePhotos) (*UserProfilePhotos, e
 // GetFile get basic info about a file and prepare it for downloading. For the moment, bots can download files of up to 20MB in size. On success, a File object is returned. The file can then be downloaded via the link https://api.telegram.org/file/bot<token>/<file_path>, where <file_path> is taken from the response. It is guaranteed that the link will be valid for at least 1 hour. When the link expires, a new one can be requested by calling getFile again.
 //
 // Note: This function may not preserve the original file name and MIME type. You should save the file's MIME type and name (if available) when the File object is received.
+//
+// If the Bot API server is launched in local mode (see SetLocalMode), FilePath of the result contains an absolute path to the file on the server host.
 func (b Bot) GetFile(fid string) (*File, error) {
-	src, err := b.Do(MethodGetFile, GetFile{FileID: fid})
-	if err != nil {
-		return nil, err
-	}
+	return b.GetFileContext(context.Background(), fid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetFileContext is the same as GetFile, but with a context.Context.
+func (b Bot) GetFileContext(ctx context.Context, fid string) (*File, error) {
+	resp, err := b.DoContext(ctx, MethodGetFile, GetFile{FileID: fid})
+	if err != nil {
 		return nil, err
 	}
 
@@ -1462,13 +1411,13 @@ func NewKick(chatID int64, userID int) KickChatMember {
 //
 // Note: In regular groups (non-supergroups), this method will only work if the 'All Members Are Admins' setting is off in the target group. Otherwise members may only be removed by the group's creator or by the member that added them.
 func (b Bot) KickChatMember(p KickChatMember) (bool, error) {
-	src, err := b.Do(MethodKickChatMember, p)
-	if err != nil {
-		return false, err
-	}
+	return b.KickChatMemberContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// KickChatMemberContext is the same as KickChatMember, but with a context.Context.
+func (b Bot) KickChatMemberContext(ctx context.Context, p KickChatMember) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodKickChatMember, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -1482,13 +1431,13 @@ func (b Bot) KickChatMember(p KickChatMember) (bool, error) {
 
 // UnbanChatMember unban a previously kicked user in a supergroup or channel. The user will not return to the group or channel automatically, but will be able to join via link, etc. The bot must be an administrator for this to work. Returns True on success.
 func (b Bot) UnbanChatMember(cid int64, uid int) (bool, error) {
-	src, err := b.Do(MethodUnbanChatMember, UnbanChatMember{ChatID: cid, UserID: uid})
-	if err != nil {
-		return false, err
-	}
+	return b.UnbanChatMemberContext(context.Background(), cid, uid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// UnbanChatMemberContext is the same as UnbanChatMember, but with a context.Context.
+func (b Bot) UnbanChatMemberContext(ctx context.Context, cid int64, uid int) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodUnbanChatMember, UnbanChatMember{ChatID: cid, UserID: uid})
+	if err != nil {
 		return false, err
 	}
 
@@ -1510,13 +1459,13 @@ func NewRestrict(chatID int64, userID int, permissions ChatPermissions) Restrict
 
 // restrict a user in a supergroup. The bot must be an administrator in the supergroup for this to work and must have the appropriate admin rights. Pass True for all permissions to lift restrictions from a user. Returns True on success.
 func (b Bot) RestrictChatMember(p RestrictChatMember) (bool, error) {
-	src, err := b.Do(MethodRestrictChatMember, p)
-	if err != nil {
-		return false, err
-	}
+	return b.RestrictChatMemberContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// RestrictChatMemberContext is the same as RestrictChatMember, but with a context.Context.
+func (b Bot) RestrictChatMemberContext(ctx context.Context, p RestrictChatMember) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodRestrictChatMember, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -1537,13 +1486,13 @@ func NewPromote(chatID int64, userID int) PromoteChatMember {
 
 // PromoteChatMember promote or demote a user in a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Pass False for all boolean  to demote a user. Returns True on success.
 func (b Bot) PromoteChatMember(p PromoteChatMember) (bool, error) {
-	src, err := b.Do(MethodPromoteChatMember, p)
-	if err != nil {
-		return false, err
-	}
+	return b.PromoteChatMemberContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// PromoteChatMemberContext is the same as PromoteChatMember, but with a context.Context.
+func (b Bot) PromoteChatMemberContext(ctx context.Context, p PromoteChatMember) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodPromoteChatMember, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -1557,13 +1506,13 @@ func (b Bot) PromoteChatMember(p PromoteChatMember) (bool, error) {
 
 // SetChatAdministratorCustomTitle method to set a custom title for an administrator in a supergroup promoted by the b. Returns True on success.
 func (b Bot) SetChatAdministratorCustomTitle(p SetChatAdministratorCustomTitle) (bool, error) {
-	src, err := b.Do(MethodSetChatAdministratorCustomTitle, p)
-	if err != nil {
-		return false, err
-	}
+	return b.SetChatAdministratorCustomTitleContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SetChatAdministratorCustomTitleContext is the same as SetChatAdministratorCustomTitle, but with a context.Context.
+func (b Bot) SetChatAdministratorCustomTitleContext(ctx context.Context, p SetChatAdministratorCustomTitle) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSetChatAdministratorCustomTitle, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -1577,13 +1526,13 @@ func (b Bot) SetChatAdministratorCustomTitle(p SetChatAdministratorCustomTitle)
 
 // SetChatPermissions set default chat permissions for all members. The bot must be an administrator in the group or a supergroup for this to work and must have the can_restrict_members admin rights. Returns True on success.
 func (b Bot) SetChatPermissions(p SetChatPermissions) (bool, error) {
-	src, err := b.Do(MethodSetChatPermissions, p)
-	if err != nil {
-		return false, err
-	}
+	return b.SetChatPermissionsContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SetChatPermissionsContext is the same as SetChatPermissions, but with a context.Context.
+func (b Bot) SetChatPermissionsContext(ctx context.Context, p SetChatPermissions) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSetChatPermissions, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -1597,13 +1546,13 @@ func (b Bot) SetChatPermissions(p SetChatPermissions) (bool, error) {
 
 // ExportChatInviteLink export an invite link to a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Returns exported invite link as String on success.
 func (b Bot) ExportChatInviteLink(cid int64) (string, error) {
-	src, err := b.Do(MethodExportChatInviteLink, ExportChatInviteLink{ChatID: cid})
-	if err != nil {
-		return "", err
-	}
+	return b.ExportChatInviteLinkContext(context.Background(), cid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// ExportChatInviteLinkContext is the same as ExportChatInviteLink, but with a context.Context.
+func (b Bot) ExportChatInviteLinkContext(ctx context.Context, cid int64) (string, error) {
+	resp, err := b.DoContext(ctx, MethodExportChatInviteLink, ExportChatInviteLink{ChatID: cid})
+	if err != nil {
 		return "", err
 	}
 
@@ -1617,29 +1566,20 @@ func (b Bot) ExportChatInviteLink(cid int64) (string, error) {
 
 // SetChatPhoto set a new profile photo for the chat. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Returns True on success.
 func (b Bot) SetChatPhoto(cid int64, photo *InputFile) (bool, error) {
+	return b.SetChatPhotoContext(context.Background(), cid, photo)
+}
+
+// SetChatPhotoContext is the same as SetChatPhoto, but with a context.Context.
+func (b Bot) SetChatPhotoContext(ctx context.Context, cid int64, photo *InputFile) (bool, error) {
 	params := make(map[string]string)
 	params["chat_id"] = strconv.FormatInt(cid, 10)
 
-	var err error
-	if params["photo"], err = b.marshler.MarshalToString(photo); err != nil {
-		return false, err
-	}
-
-	files := make([]*InputFile, 0)
-	if photo.IsAttachment() {
-		files = append(files, photo)
-	}
-
-	src, err := b.Upload(MethodSetChatPhoto, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"photo": photo})
+	resp, err := b.UploadContext(ctx, MethodSetChatPhoto, params, parts...)
 	if err != nil {
 		return false, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return false, err
-	}
-
 	var result bool
 	if err = b.marshler.Unmarshal(resp.Result, &result); err != nil {
 		return false, err
@@ -1650,13 +1590,13 @@ func (b Bot) SetChatPhoto(cid int64, photo *InputFile) (bool, error) {
 
 // DeleteChatPhoto delete a chat photo. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Returns True on success.
 func (b Bot) DeleteChatPhoto(cid int64) (bool, error) {
-	src, err := b.Do(MethodDeleteChatPhoto, DeleteChatPhoto{ChatID: cid})
-	if err != nil {
-		return false, err
-	}
+	return b.DeleteChatPhotoContext(context.Background(), cid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// DeleteChatPhotoContext is the same as DeleteChatPhoto, but with a context.Context.
+func (b Bot) DeleteChatPhotoContext(ctx context.Context, cid int64) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodDeleteChatPhoto, DeleteChatPhoto{ChatID: cid})
+	if err != nil {
 		return false, err
 	}
 
@@ -1670,13 +1610,13 @@ func (b Bot) DeleteChatPhoto(cid int64) (bool, error) {
 
 // SetChatTitle change the title of a chat. Titles can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Returns True on success.
 func (b Bot) SetChatTitle(cid int64, title string) (bool, error) {
-	src, err := b.Do(MethodSetChatTitle, SetChatTitle{ChatID: cid, Title: title})
-	if err != nil {
-		return false, err
-	}
+	return b.SetChatTitleContext(context.Background(), cid, title)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SetChatTitleContext is the same as SetChatTitle, but with a context.Context.
+func (b Bot) SetChatTitleContext(ctx context.Context, cid int64, title string) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSetChatTitle, SetChatTitle{ChatID: cid, Title: title})
+	if err != nil {
 		return false, err
 	}
 
@@ -1690,13 +1630,13 @@ func (b Bot) SetChatTitle(cid int64, title string) (bool, error) {
 
 // SetChatDescription change the description of a group, a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Returns True on success.
 func (b Bot) SetChatDescription(cid int64, txt string) (bool, error) {
-	src, err := b.Do(MethodSetChatDescription, SetChatDescription{ChatID: cid, Description: txt})
-	if err != nil {
-		return false, err
-	}
+	return b.SetChatDescriptionContext(context.Background(), cid, txt)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SetChatDescriptionContext is the same as SetChatDescription, but with a context.Context.
+func (b Bot) SetChatDescriptionContext(ctx context.Context, cid int64, txt string) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSetChatDescription, SetChatDescription{ChatID: cid, Description: txt})
+	if err != nil {
 		return false, err
 	}
 
@@ -1717,13 +1657,13 @@ func NewPin(chatID int64, messageID int) PinChatMessage {
 
 // PinChatMessage pin a message in a group, a supergroup, or a channel. The bot must be an administrator in the chat for this to work and must have the ‘can_pin_messages’ admin right in the supergroup or ‘can_edit_messages’ admin right in the channel. Returns True on success.
 func (b Bot) PinChatMessage(p PinChatMessage) (bool, error) {
-	src, err := b.Do(MethodPinChatMessage, p)
-	if err != nil {
-		return false, err
-	}
+	return b.PinChatMessageContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// PinChatMessageContext is the same as PinChatMessage, but with a context.Context.
+func (b Bot) PinChatMessageContext(ctx context.Context, p PinChatMessage) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodPinChatMessage, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -1737,13 +1677,13 @@ func (b Bot) PinChatMessage(p PinChatMessage) (bool, error) {
 
 // UnpinChatMessage unpin a message in a group, a supergroup, or a channel. The bot must be an administrator in the chat for this to work and must have the ‘can_pin_messages’ admin right in the supergroup or ‘can_edit_messages’ admin right in the channel. Returns True on success.
 func (b Bot) UnpinChatMessage(cid int64) (bool, error) {
-	src, err := b.Do(MethodUnpinChatMessage, UnpinChatMessage{ChatID: cid})
-	if err != nil {
-		return false, err
-	}
+	return b.UnpinChatMessageContext(context.Background(), cid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// UnpinChatMessageContext is the same as UnpinChatMessage, but with a context.Context.
+func (b Bot) UnpinChatMessageContext(ctx context.Context, cid int64) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodUnpinChatMessage, UnpinChatMessage{ChatID: cid})
+	if err != nil {
 		return false, err
 	}
 
@@ -1757,13 +1697,13 @@ func (b Bot) UnpinChatMessage(cid int64) (bool, error) {
 
 // LeaveChat leave a group, supergroup or channel. Returns True on success.
 func (b Bot) LeaveChat(cid int64) (bool, error) {
-	src, err := b.Do(MethodLeaveChat, LeaveChat{ChatID: cid})
-	if err != nil {
-		return false, err
-	}
+	return b.LeaveChatContext(context.Background(), cid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// LeaveChatContext is the same as LeaveChat, but with a context.Context.
+func (b Bot) LeaveChatContext(ctx context.Context, cid int64) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodLeaveChat, LeaveChat{ChatID: cid})
+	if err != nil {
 		return false, err
 	}
 
@@ -1777,13 +1717,13 @@ func (b Bot) LeaveChat(cid int64) (bool, error) {
 
 // GetChat get up to date information about the chat (current name of the user for one-on-one conversations, current username of a user, group or channel, etc.). Returns a Chat object on success.
 func (b Bot) GetChat(cid int64) (*Chat, error) {
-	src, err := b.Do(MethodGetChat, GetChat{ChatID: cid})
-	if err != nil {
-		return nil, err
-	}
+	return b.GetChatContext(context.Background(), cid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetChatContext is the same as GetChat, but with a context.Context.
+func (b Bot) GetChatContext(ctx context.Context, cid int64) (*Chat, error) {
+	resp, err := b.DoContext(ctx, MethodGetChat, GetChat{ChatID: cid})
+	if err != nil {
 		return nil, err
 	}
 
@@ -1797,13 +1737,13 @@ func (b Bot) GetChat(cid int64) (*Chat, error) {
 
 // GetChatAdministrators get a list of administrators in a chat. On success, returns an Array of ChatMember objects that contains information about all chat administrators except other bots. If the chat is a group or a supergroup and no administrators were appointed, only the creator will be returned.
 func (b Bot) GetChatAdministrators(cid int64) ([]*ChatMember, error) {
-	src, err := b.Do(MethodGetChatAdministrators, GetChatAdministrators{ChatID: cid})
-	if err != nil {
-		return nil, err
-	}
+	return b.GetChatAdministratorsContext(context.Background(), cid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetChatAdministratorsContext is the same as GetChatAdministrators, but with a context.Context.
+func (b Bot) GetChatAdministratorsContext(ctx context.Context, cid int64) ([]*ChatMember, error) {
+	resp, err := b.DoContext(ctx, MethodGetChatAdministrators, GetChatAdministrators{ChatID: cid})
+	if err != nil {
 		return nil, err
 	}
 
@@ -1817,13 +1757,13 @@ func (b Bot) GetChatAdministrators(cid int64) ([]*ChatMember, error) {
 
 // GetChatMembersCount get the number of members in a chat. Returns Int on success.
 func (b Bot) GetChatMembersCount(cid int64) (int, error) {
-	src, err := b.Do(MethodGetChatMembersCount, GetChatMembersCount{ChatID: cid})
-	if err != nil {
-		return 0, err
-	}
+	return b.GetChatMembersCountContext(context.Background(), cid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetChatMembersCountContext is the same as GetChatMembersCount, but with a context.Context.
+func (b Bot) GetChatMembersCountContext(ctx context.Context, cid int64) (int, error) {
+	resp, err := b.DoContext(ctx, MethodGetChatMembersCount, GetChatMembersCount{ChatID: cid})
+	if err != nil {
 		return 0, err
 	}
 
@@ -1837,13 +1777,13 @@ func (b Bot) GetChatMembersCount(cid int64) (int, error) {
 
 // GetChatMember get information about a member of a chat. Returns a ChatMember object on success.
 func (b Bot) GetChatMember(cid int64, uid int) (*ChatMember, error) {
-	src, err := b.Do(MethodGetChatMember, GetChatMember{ChatID: cid, UserID: uid})
-	if err != nil {
-		return nil, err
-	}
+	return b.GetChatMemberContext(context.Background(), cid, uid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetChatMemberContext is the same as GetChatMember, but with a context.Context.
+func (b Bot) GetChatMemberContext(ctx context.Context, cid int64, uid int) (*ChatMember, error) {
+	resp, err := b.DoContext(ctx, MethodGetChatMember, GetChatMember{ChatID: cid, UserID: uid})
+	if err != nil {
 		return nil, err
 	}
 
@@ -1857,13 +1797,13 @@ func (b Bot) GetChatMember(cid int64, uid int) (*ChatMember, error) {
 
 // SetChatStickerSet set a new group sticker set for a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Use the field can_set_sticker_set optionally returned in getChat requests to check if the bot can use this method. Returns True on success.
 func (b Bot) SetChatStickerSet(cid int64, name string) (bool, error) {
-	src, err := b.Do(MethodSetChatStickerSet, SetChatStickerSet{ChatID: cid, StickerSetName: name})
-	if err != nil {
-		return false, err
-	}
+	return b.SetChatStickerSetContext(context.Background(), cid, name)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SetChatStickerSetContext is the same as SetChatStickerSet, but with a context.Context.
+func (b Bot) SetChatStickerSetContext(ctx context.Context, cid int64, name string) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSetChatStickerSet, SetChatStickerSet{ChatID: cid, StickerSetName: name})
+	if err != nil {
 		return false, err
 	}
 
@@ -1877,13 +1817,13 @@ func (b Bot) SetChatStickerSet(cid int64, name string) (bool, error) {
 
 // DeleteChatStickerSet delete a group sticker set from a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Use the field can_set_sticker_set optionally returned in getChat requests to check if the bot can use this method. Returns True on success.
 func (b Bot) DeleteChatStickerSet(cid int64) (bool, error) {
-	src, err := b.Do(MethodDeleteChatStickerSet, DeleteChatStickerSet{ChatID: cid})
-	if err != nil {
-		return false, err
-	}
+	return b.DeleteChatStickerSetContext(context.Background(), cid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// DeleteChatStickerSetContext is the same as DeleteChatStickerSet, but with a context.Context.
+func (b Bot) DeleteChatStickerSetContext(ctx context.Context, cid int64) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodDeleteChatStickerSet, DeleteChatStickerSet{ChatID: cid})
+	if err != nil {
 		return false, err
 	}
 
@@ -1901,13 +1841,13 @@ func NewAnswerCallback(callbackQueryID string) AnswerCallbackQuery {
 
 // AnswerCallbackQuery send answers to callback queries sent from inline keyboards. The answer will be displayed to the user as a notification at the top of the chat screen or as an alert. On success, True is returned.
 func (b Bot) AnswerCallbackQuery(p AnswerCallbackQuery) (bool, error) {
-	src, err := b.Do(MethodAnswerCallbackQuery, p)
-	if err != nil {
-		return false, err
-	}
+	return b.AnswerCallbackQueryContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// AnswerCallbackQueryContext is the same as AnswerCallbackQuery, but with a context.Context.
+func (b Bot) AnswerCallbackQueryContext(ctx context.Context, p AnswerCallbackQuery) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodAnswerCallbackQuery, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -1921,13 +1861,13 @@ func (b Bot) AnswerCallbackQuery(p AnswerCallbackQuery) (bool, error) {
 
 // SetMyCommands change the list of the bot's commands. Returns True on success.
 func (b Bot) SetMyCommands(p SetMyCommands) (bool, error) {
-	src, err := b.Do(MethodSetMyCommands, p)
-	if err != nil {
-		return false, err
-	}
+	return b.SetMyCommandsContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SetMyCommandsContext is the same as SetMyCommands, but with a context.Context.
+func (b Bot) SetMyCommandsContext(ctx context.Context, p SetMyCommands) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSetMyCommands, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -1942,13 +1882,13 @@ func (b Bot) SetMyCommands(p SetMyCommands) (bool, error) {
 // GetMyCommands get the current list of the bot's commands. Requires no parameters. Returns Array of BotCommand on
 // success.
 func (b Bot) GetMyCommands() ([]*BotCommand, error) {
-	src, err := b.Do(MethodGetMyCommands, nil)
-	if err != nil {
-		return nil, err
-	}
+	return b.GetMyCommandsContext(context.Background())
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetMyCommandsContext is the same as GetMyCommands, but with a context.Context.
+func (b Bot) GetMyCommandsContext(ctx context.Context) ([]*BotCommand, error) {
+	resp, err := b.DoContext(ctx, MethodGetMyCommands, nil)
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,82 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+type (
+	// ChatMigratedError represents an error of request to the group which has been migrated to a supergroup.
+	ChatMigratedError struct {
+		// Identifier of the group used in request
+		OldChatID int64
+
+		// Identifier of the supergroup to which the group has been migrated
+		NewChatID int64
+
+		// Original error returned by Telegram
+		Err *Error
+	}
+
+	// MigrationHook is called by Bot after the group has been migrated to a supergroup and before the request will
+	// be repeated with the new chat identifier.
+	MigrationHook func(ctx context.Context, oldChatID, newChatID int64)
+)
+
+// SetMigrationHook enables repeating of requests to migrated groups. Each such request is repeated once with the
+// new chat identifier after hook is called. Nil hook disables repeating, then such requests returns
+// *ChatMigratedError. Uploads of files which are not io.Seeker are never repeated and hook is not called for them.
+func (b *Bot) SetMigrationHook(hook MigrationHook) {
+	if b == nil {
+		b = new(Bot)
+	}
+
+	b.migrated = hook
+}
+
+func (e ChatMigratedError) Error() string {
+	return fmt.Sprintf("group %d has been migrated to supergroup %d: %v", e.OldChatID, e.NewChatID, e.Err)
+}
+
+func (e ChatMigratedError) Unwrap() error { return e.Err }
+
+// migrate checks that err is caused by migration of the chatID group to a supergroup and converts err into
+// *ChatMigratedError. It returns true only if the repeatable request must be repeated with the new chat identifier,
+// in this case migration hook is already called.
+func (b Bot) migrate(ctx context.Context, err error, chatID string, repeatable bool) (*ChatMigratedError, bool) {
+	var tgErr *Error
+	if !xerrors.As(err, &tgErr) || tgErr.MigrateToChatID() == 0 {
+		return nil, false
+	}
+
+	oldChatID, _ := strconv.ParseInt(chatID, 10, 64)
+	result := &ChatMigratedError{
+		OldChatID: oldChatID,
+		NewChatID: tgErr.MigrateToChatID(),
+		Err:       tgErr,
+	}
+
+	if b.migrated == nil || !repeatable {
+		return result, false
+	}
+
+	b.migrated(ctx, result.OldChatID, result.NewChatID)
+
+	return result, true
+}
+
+// replaceChatID returns a copy of JSON-encoded payload with the new chat_id.
+func replaceChatID(src []byte, chatID int64) ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(src, &fields); err != nil {
+		return nil, err
+	}
+
+	fields["chat_id"] = json.RawMessage(strconv.FormatInt(chatID, 10))
+
+	return json.Marshal(fields)
+}
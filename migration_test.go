@@ -0,0 +1,72 @@
+package telegram
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+func TestBotMigration(t *testing.T) {
+	handler := func(ctx *http.RequestCtx) {
+		if string(ctx.Request.Body()) == `{"chat_id":-100500,"text":"hello"}` {
+			ctx.SetBodyString(`{"ok":true,"result":{"message_id":1,"chat":{"id":-100500,"type":"supergroup"}}}`)
+
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusBadRequest)
+		ctx.SetBodyString(`{"ok":false,"error_code":400,"description":"Bad Request: group chat was upgraded to ` +
+			`a supergroup chat","parameters":{"migrate_to_chat_id":-100500}}`)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		b, stop := newTestBot(handler)
+		defer stop()
+
+		_, err := b.SendMessage(NewMessage(-42, "hello"))
+		assert.True(t, xerrors.Is(err, ErrBadRequest))
+
+		var migrated *ChatMigratedError
+		if assert.True(t, xerrors.As(err, &migrated)) {
+			assert.Equal(t, int64(-42), migrated.OldChatID)
+			assert.Equal(t, int64(-100500), migrated.NewChatID)
+		}
+	})
+	t.Run("hook", func(t *testing.T) {
+		b, stop := newTestBot(handler)
+		defer stop()
+
+		var oldID, newID int64
+
+		b.SetMigrationHook(func(_ context.Context, oldChatID, newChatID int64) { oldID, newID = oldChatID, newChatID })
+
+		msg, err := b.SendMessage(NewMessage(-42, "hello"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-100500), msg.Chat.ID)
+		assert.Equal(t, int64(-42), oldID)
+		assert.Equal(t, int64(-100500), newID)
+	})
+	t.Run("not seeker", func(t *testing.T) {
+		b, stop := newTestBot(handler)
+		defer stop()
+
+		var called bool
+
+		b.SetMigrationHook(func(context.Context, int64, int64) { called = true })
+
+		_, err := b.SendDocument(NewDocument(-42, NewInputFileReader("file.txt", io.MultiReader(
+			strings.NewReader("document")))))
+
+		var migrated *ChatMigratedError
+		if assert.True(t, xerrors.As(err, &migrated)) {
+			assert.Equal(t, int64(-100500), migrated.NewChatID)
+		}
+
+		assert.False(t, called)
+	})
+}
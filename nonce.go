@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+type (
+	// NonceStore keeps nonces of Telegram Passport authorization requests until credentials with them are received,
+	// so each nonce can be accepted only once.
+	NonceStore interface {
+		// Add saves the nonce of a new authorization request.
+		Add(ctx context.Context, nonce string) error
+
+		// Use removes the nonce of received credentials. It returns ErrNonce if the nonce is unknown, expired or
+		// already used.
+		Use(ctx context.Context, nonce string) error
+	}
+
+	// MemoryNonceStore is a NonceStore which keeps nonces in memory.
+	MemoryNonceStore struct {
+		ttl    time.Duration
+		nonces map[string]time.Time
+		mu     sync.Mutex
+	}
+)
+
+// ErrNonce describes credentials with unknown or already used nonce.
+var ErrNonce = xerrors.New("unknown or already used passport nonce") //nolint: gochecknoglobals
+
+// NewNonce generates a new cryptographically secure random nonce.
+func NewNonce() (string, error) {
+	nonce := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(nonce), nil
+}
+
+// SetNonceStore enables saving of nonces generated by NewPassportAuth and rejecting of credentials with unknown or
+// reused nonces in DecryptPassportData. Nil store disables it.
+func (b *Bot) SetNonceStore(s NonceStore) {
+	if b == nil {
+		b = new(Bot)
+	}
+
+	b.nonces = s
+}
+
+// NewMemoryNonceStore creates a new empty MemoryNonceStore. Nonces older than ttl are rejected, zero ttl means that
+// nonces never expire.
+func NewMemoryNonceStore(ttl time.Duration) *MemoryNonceStore {
+	return &MemoryNonceStore{ttl: ttl, nonces: make(map[string]time.Time)}
+}
+
+// Add saves the nonce and removes expired ones.
+func (s *MemoryNonceStore) Add(_ context.Context, nonce string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	for n, created := range s.nonces {
+		if s.expired(created, now) {
+			delete(s.nonces, n)
+		}
+	}
+
+	s.nonces[nonce] = now
+
+	return nil
+}
+
+// Use removes the nonce or returns ErrNonce if it's unknown or expired.
+func (s *MemoryNonceStore) Use(_ context.Context, nonce string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created, ok := s.nonces[nonce]
+	if !ok {
+		return ErrNonce
+	}
+
+	delete(s.nonces, nonce)
+
+	if s.expired(created, time.Now()) {
+		return ErrNonce
+	}
+
+	return nil
+}
+
+func (s *MemoryNonceStore) expired(created, now time.Time) bool {
+	return s.ttl > 0 && now.Sub(created) > s.ttl
+}
@@ -0,0 +1,37 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	t.Run("once", func(t *testing.T) {
+		s := NewMemoryNonceStore(0)
+		assert.NoError(t, s.Add(context.Background(), "abc"))
+		assert.NoError(t, s.Use(context.Background(), "abc"))
+		assert.Equal(t, ErrNonce, s.Use(context.Background(), "abc"))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		assert.Equal(t, ErrNonce, NewMemoryNonceStore(0).Use(context.Background(), "abc"))
+	})
+	t.Run("expired", func(t *testing.T) {
+		s := NewMemoryNonceStore(time.Millisecond)
+		assert.NoError(t, s.Add(context.Background(), "abc"))
+		time.Sleep(2 * time.Millisecond)
+		assert.Equal(t, ErrNonce, s.Use(context.Background(), "abc"))
+	})
+}
+
+func TestNewNonce(t *testing.T) {
+	a, err := NewNonce()
+	assert.NoError(t, err)
+	assert.Len(t, a, 64)
+
+	b, err := NewNonce()
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
@@ -0,0 +1,107 @@
+package telegram
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// OffsetStore keeps the identifier of the next expected update between restarts.
+	OffsetStore interface {
+		// Load returns the saved offset or zero if nothing is saved yet.
+		Load(ctx context.Context) (int, error)
+
+		// Save stores the offset.
+		Save(ctx context.Context, offset int) error
+	}
+
+	// MemoryOffsetStore is an OffsetStore which keeps offset in memory. Useful for tests.
+	MemoryOffsetStore struct {
+		offset int
+		mu     sync.RWMutex
+	}
+
+	// FileOffsetStore is an OffsetStore which keeps offset in a file as plain text. The file is replaced atomically
+	// on each save.
+	FileOffsetStore struct {
+		path string
+		mu   sync.Mutex
+	}
+)
+
+// NewMemoryOffsetStore creates a new empty MemoryOffsetStore.
+func NewMemoryOffsetStore() *MemoryOffsetStore { return new(MemoryOffsetStore) }
+
+// Load returns the saved offset.
+func (s *MemoryOffsetStore) Load(context.Context) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.offset, nil
+}
+
+// Save stores the offset.
+func (s *MemoryOffsetStore) Save(_ context.Context, offset int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.offset = offset
+
+	return nil
+}
+
+// NewFileOffsetStore creates a new FileOffsetStore which keeps offset in the file by path.
+func NewFileOffsetStore(path string) *FileOffsetStore { return &FileOffsetStore{path: path} }
+
+// Load reads the saved offset from file. Missing file means zero offset.
+func (s *FileOffsetStore) Load(context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	src, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(src)))
+}
+
+// Save writes the offset into temporary file and renames it to the store path.
+func (s *FileOffsetStore) Save(_ context.Context, offset int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(strconv.Itoa(offset)); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
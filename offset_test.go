@@ -0,0 +1,47 @@
+package telegram
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffsetStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "offset")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	for name, s := range map[string]OffsetStore{
+		"memory": NewMemoryOffsetStore(),
+		"file":   NewFileOffsetStore(filepath.Join(dir, "offset")),
+	} {
+		s := s
+
+		t.Run(name, func(t *testing.T) {
+			offset, err := s.Load(context.Background())
+			assert.NoError(t, err)
+			assert.Zero(t, offset)
+
+			assert.NoError(t, s.Save(context.Background(), 42))
+			assert.NoError(t, s.Save(context.Background(), 43))
+
+			offset, err = s.Load(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 43, offset)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid")
+		assert.NoError(t, ioutil.WriteFile(path, []byte("abc"), 0600))
+
+		_, err := NewFileOffsetStore(path).Load(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -1,8 +1,8 @@
 package telegram
 
-import "errors"
-
-/*
+import (
+	"bytes"
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -11,7 +11,15 @@ import "errors"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/base64"
-*/
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/json-iterator/go"
+	"golang.org/x/xerrors"
+)
 
 type (
 	// PassportData contains information about Telegram Passport data shared with the bot by the user.
@@ -251,12 +259,15 @@ type (
 		//
 		// Important: For security purposes it should be a cryptographically secure unique identifier of the request. In particular, it should be long enough and it should be generated using a cryptographically secure pseudorandom number generator. You should never accept credentials with the same nonce twice.
 		Nonce string `json:"nonce"`
+
+		// URL to which the user will be redirected after the authorization, optional
+		CallbackURL string `json:"callback_url,omitempty"`
 	}
 
 	// PassportScope represents the data to be requested.
 	PassportScope struct {
 		// List of requested elements, each type may be used only once in the entire array of PassportScopeElement objects
-		Data []*PassportScopeElement `json:"data"`
+		Data []PassportScopeElement `json:"data"`
 
 		// Scope version, must be 1
 		V int `json:"v"`
@@ -523,26 +534,111 @@ type (
 		// Secret of encrypted file
 		Secret string `json:"secret"`
 	}
+
+	// IDDocument represents a decrypted identity document: passport, internal passport, driver license or identity
+	// card.
+	IDDocument struct {
+		// Document data
+		Data *IDDocumentData
+
+		// Front side of the document
+		FrontSide []byte
+
+		// Reverse side of the document, available for driver license and identity card
+		ReverseSide []byte
+
+		// Selfie of the user holding the document, if requested
+		Selfie []byte
+
+		// Translated versions of the document, if requested
+		Translation [][]byte
+	}
+
+	// PassportDocument represents a decrypted document which contains only files: utility bill, bank statement,
+	// rental agreement, passport registration or temporary registration.
+	PassportDocument struct {
+		// Files of the document
+		Files [][]byte
+
+		// Translated versions of the document, if requested
+		Translation [][]byte
+	}
+
+	// DecryptedPassportData represents all decrypted Telegram Passport elements shared with the bot by the user.
+	// Elements which was not shared are nil.
+	DecryptedPassportData struct {
+		// Decrypted credentials with bot-specified nonce
+		Credentials *Credentials
+
+		// Source encrypted elements
+		Elements []*EncryptedPassportElement
+
+		// Personal Details
+		PersonalDetails *PersonalDetails
+
+		// Passport
+		Passport *IDDocument
+
+		// Internal Passport
+		InternalPassport *IDDocument
+
+		// Driver License
+		DriverLicense *IDDocument
+
+		// Identity Card
+		IdentityCard *IDDocument
+
+		// Address
+		Address *ResidentialAddress
+
+		// Utility Bill
+		UtilityBill *PassportDocument
+
+		// Bank Statement
+		BankStatement *PassportDocument
+
+		// Rental Agreement
+		RentalAgreement *PassportDocument
+
+		// Registration Page in the Internal Passport
+		PassportRegistration *PassportDocument
+
+		// Temporary Registration
+		TemporaryRegistration *PassportDocument
+
+		// Phone number
+		PhoneNumber string
+
+		// Email
+		Email string
+	}
 )
 
-var ErrNotEqual = errors.New("credentials hash and credentials data hash is not equal")
+// Telegram Passport decryption errors.
+var (
+	ErrNotEqual      = xerrors.New("credentials hash and credentials data hash is not equal") //nolint: gochecknoglobals
+	ErrPassportData  = xerrors.New("invalid encrypted passport data")                         //nolint: gochecknoglobals
+	ErrPassportType  = xerrors.New("unexpected type of passport element")                     //nolint: gochecknoglobals
+	ErrNoCredentials = xerrors.New("no credentials for passport element")                     //nolint: gochecknoglobals
+	ErrPassportScope = xerrors.New("invalid passport scope")                                  //nolint: gochecknoglobals
+)
 
 // SetPassportDataErrors informs a user that some of the Telegram Passport elements they provided contains errors. The user will not be able to re-submit their Passport to you until the errors are fixed (the contents of the field for which you returned the error must change). Returns True on success.
 //
 // Use this if the data submitted by the user doesn't satisfy the standards your service requires for any reason. For example, if a birthday date seems invalid, a submitted document is blurry, a scan shows evidence of tampering, etc. Supply some details in the error message to make sure the user knows how to correct the issues.
 func (b Bot) SetPassportDataErrors(uid int, errors ...PassportElementError) (bool, error) {
-	src, err := b.Do(MethodSetPassportDataErrors, SetPassportDataErrors{
+	return b.SetPassportDataErrorsContext(context.Background(), uid, errors...)
+}
+
+// SetPassportDataErrorsContext is the same as SetPassportDataErrors, but with a context.Context.
+func (b Bot) SetPassportDataErrorsContext(ctx context.Context, uid int, errors ...PassportElementError) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSetPassportDataErrors, SetPassportDataErrors{
 		UserID: uid, Errors: errors,
 	})
 	if err != nil {
 		return false, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return false, err
-	}
-
 	var result bool
 	if err = b.marshler.Unmarshal(resp.Result, &result); err != nil {
 		return false, err
@@ -551,312 +647,675 @@ func (b Bot) SetPassportDataErrors(uid int, errors ...PassportElementError) (boo
 	return result, nil
 }
 
-/* TODO(toby3d)
-func (b *Bot) DecryptFile(pf *PassportFile, fc *FileCredentials) (data []byte, err error) {
-	secret, err := decodeField(fc.Secret)
-	if err != nil {
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorDataField) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorDataField) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorDataField) PassportElementErrorType() string { return e.Type }
+
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorFrontSide) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorFrontSide) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorFrontSide) PassportElementErrorType() string { return e.Type }
+
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorReverseSide) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorReverseSide) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorReverseSide) PassportElementErrorType() string { return e.Type }
+
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorSelfie) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorSelfie) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorSelfie) PassportElementErrorType() string { return e.Type }
+
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorFile) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorFile) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorFile) PassportElementErrorType() string { return e.Type }
+
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorFiles) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorFiles) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorFiles) PassportElementErrorType() string { return e.Type }
+
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorTranslationFile) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorTranslationFile) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorTranslationFile) PassportElementErrorType() string { return e.Type }
+
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorTranslationFiles) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorTranslationFiles) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorTranslationFiles) PassportElementErrorType() string { return e.Type }
+
+// PassportElementErrorMessage returns the error message.
+func (e PassportElementErrorUnspecified) PassportElementErrorMessage() string { return e.Message }
+
+// PassportElementErrorSource returns the error source.
+func (e PassportElementErrorUnspecified) PassportElementErrorSource() string { return e.Source }
+
+// PassportElementErrorType returns the type of element which has the error.
+func (e PassportElementErrorUnspecified) PassportElementErrorType() string { return e.Type }
+
+// NewPassportAuth validates scope and creates authorization request parameters with a new nonce for the bot public
+// key in PEM format. The nonce is saved in NonceStore, if it's set.
+func (b Bot) NewPassportAuth(ctx context.Context, scope *PassportScope, publicKey string) (*Auth, error) {
+	if err := scope.Validate(); err != nil {
 		return nil, err
 	}
 
-	hash, err := decodeField(fc.FileHash)
+	botID, err := b.id()
 	if err != nil {
 		return nil, err
 	}
 
-	key, iv := decryptSecretHash(secret, hash)
-
-	file, err := b.GetFile(pf.FileID)
+	nonce, err := NewNonce()
 	if err != nil {
 		return nil, err
 	}
 
-	if _, data, err = b.Client.Get(nil, b.NewFileURL(file.FilePath).String()); err != nil {
-		return nil, err
+	if b.nonces != nil {
+		if err = b.nonces.Add(ctx, nonce); err != nil {
+			return nil, err
+		}
 	}
 
-	if data, err = decryptData(key, iv, data); err != nil {
-		return nil, err
-	}
+	return &Auth{BotID: botID, Scope: scope, PublicKey: publicKey, Nonce: nonce}, nil
+}
 
-	if !match(hash, data) {
-		err = ErrNotEqual
-		return nil, err
+// id returns the bot identifier from User or from the access token.
+func (b Bot) id() (int, error) {
+	if b.User != nil && b.User.ID != 0 {
+		return b.User.ID, nil
 	}
 
-	offset := int(data[0])
-	data = data[offset:]
-
-	return data, nil
+	return strconv.Atoi(strings.SplitN(b.AccessToken, ":", 2)[0])
 }
 
-func (dc *DataCredentials) decrypt(d string) (data []byte, err error) {
-	secret, err := decodeField(dc.Secret)
+// URL returns the tg://resolve deep link which opens the authorization request in Telegram app.
+func (a Auth) URL() (string, error) {
+	scope, err := json.ConfigFastest.Marshal(a.Scope)
 	if err != nil {
-		return
+		return "", err
 	}
 
-	hash, err := decodeField(dc.DataHash)
-	if err != nil {
-		return
+	v := make(url.Values)
+	v.Set("bot_id", strconv.Itoa(a.BotID))
+	v.Set("scope", string(scope))
+	v.Set("public_key", a.PublicKey)
+	v.Set("nonce", a.Nonce)
+
+	if a.CallbackURL != "" {
+		v.Set("callback_url", a.CallbackURL)
 	}
 
-	key, iv := decryptSecretHash(secret, hash)
+	return "tg://resolve?domain=telegrampassport&" + v.Encode(), nil
+}
+
+// Options returns the JSON-serialized parameters for Telegram.Passport.createAuthButton or Telegram.Passport.auth of
+// the JavaScript SDK.
+func (a Auth) Options() ([]byte, error) { return json.ConfigFastest.Marshal(a) }
 
-	if data, err = decodeField(d); err != nil {
-		return
+// NewPassportScope creates a new scope of version 1 with provided elements.
+func NewPassportScope(elements ...PassportScopeElement) *PassportScope {
+	return &PassportScope{Data: elements, V: 1}
+}
+
+// Validate checks that scope has version 1, each type is requested only once, one of several elements contains only
+// documents of the same kind and options are requested only for elements which supports them.
+func (ps *PassportScope) Validate() error {
+	if ps == nil || len(ps.Data) == 0 {
+		return xerrors.Errorf("no elements: %w", ErrPassportScope)
 	}
 
-	if data, err = decryptData(key, iv, data); err != nil {
-		return
+	if ps.V != 1 {
+		return xerrors.Errorf("unsupported version %d: %w", ps.V, ErrPassportScope)
 	}
 
-	if !match(hash, data) {
-		err = ErrNotEqual
+	types := make(map[string]bool)
+	add := func(e *PassportScopeElementOne) error {
+		if err := e.validate(); err != nil {
+			return err
+		}
+
+		if types[e.Type] {
+			return xerrors.Errorf("type %s is requested more than once: %w", e.Type, ErrPassportScope)
+		}
+
+		types[e.Type] = true
+
+		return nil
+	}
+
+	for i := range ps.Data {
+		switch e := ps.Data[i].(type) {
+		case *PassportScopeElementOne:
+			if err := add(e); err != nil {
+				return err
+			}
+		case *PassportScopeElementOneOfSeveral:
+			if err := e.validate(); err != nil {
+				return err
+			}
+
+			for j := range e.OneOf {
+				if err := add(e.OneOf[j]); err != nil {
+					return err
+				}
+			}
+		default:
+			return xerrors.Errorf("unsupported element %T: %w", e, ErrPassportScope)
+		}
 	}
 
-	offset := int(data[0])
-	data = data[offset:]
+	return nil
+}
+
+// PassportScopeElementSelfie returns true if the selfie with the document is requested.
+func (e *PassportScopeElementOne) PassportScopeElementSelfie() bool { return e.Selfie }
+
+// PassportScopeElementTranslation returns true if the translation of the document is requested.
+func (e *PassportScopeElementOne) PassportScopeElementTranslation() bool { return e.Translation }
+
+// PassportScopeElementSelfie returns true if the selfie with the chosen document is requested.
+func (e *PassportScopeElementOneOfSeveral) PassportScopeElementSelfie() bool { return e.Selfie }
 
-	return
+// PassportScopeElementTranslation returns true if the translation of the chosen document is requested.
+func (e *PassportScopeElementOneOfSeveral) PassportScopeElementTranslation() bool {
+	return e.Translation
 }
 
-func (ec *EncryptedCredentials) Decrypt(pk *rsa.PrivateKey) (*Credentials, error) {
-	if ec == nil || pk == nil {
-		return nil, nil
+func (e *PassportScopeElementOne) validate() error {
+	switch {
+	case e == nil:
+		return xerrors.Errorf("nil element: %w", ErrPassportScope)
+	case !isPassportElementType(e.Type):
+		return xerrors.Errorf("unsupported type %q: %w", e.Type, ErrPassportScope)
+	case e.Selfie && !isIdentityDocument(e.Type):
+		return xerrors.Errorf("selfie is not supported by %s: %w", e.Type, ErrPassportScope)
+	case e.Translation && !isIdentityDocument(e.Type) && !isAddressDocument(e.Type):
+		return xerrors.Errorf("translation is not supported by %s: %w", e.Type, ErrPassportScope)
+	case e.NativeNames && e.Type != TypePersonalDetails:
+		return xerrors.Errorf("native names are not supported by %s: %w", e.Type, ErrPassportScope)
+	default:
+		return nil
 	}
+}
 
-	data, err := decrypt(pk, ec.Secret, ec.Hash, ec.Data)
-	if err != nil {
-		return nil, err
+func (e *PassportScopeElementOneOfSeveral) validate() error {
+	if e == nil || len(e.OneOf) == 0 {
+		return xerrors.Errorf("no elements in one_of: %w", ErrPassportScope)
 	}
 
-	var c Credentials
-	if err = json.ConfigFastest.Unmarshal(data, &c); err != nil {
-		return nil, Error{Description: err.Error()}
+	for i := range e.OneOf {
+		if e.OneOf[i] == nil {
+			return xerrors.Errorf("nil element: %w", ErrPassportScope)
+		}
+
+		switch {
+		case isIdentityDocument(e.OneOf[i].Type) && isIdentityDocument(e.OneOf[0].Type):
+		case isAddressDocument(e.OneOf[i].Type) && isAddressDocument(e.OneOf[0].Type):
+			if e.Selfie {
+				return xerrors.Errorf("selfie is not supported by %s: %w", e.OneOf[i].Type, ErrPassportScope)
+			}
+		default:
+			return xerrors.Errorf("one_of must contain documents of the same kind, got %s: %w", e.OneOf[i].Type,
+				ErrPassportScope)
+		}
 	}
 
-	return &c, err
+	return nil
 }
 
-func (epe *EncryptedPassportElement) DecryptPersonalDetails(sv *SecureValue) (*PersonalDetails, error) {
-	if !epe.IsPersonalDetails() || !sv.HasData() {
-		return nil, nil
+func isPassportElementType(t string) bool {
+	switch t {
+	case TypePersonalDetails, TypeAddress, TypePhoneNumber, TypeEmail:
+		return true
+	default:
+		return isIdentityDocument(t) || isAddressDocument(t)
+	}
+}
+
+func isIdentityDocument(t string) bool {
+	switch t {
+	case TypePassport, TypeDriverLicense, TypeIdentityCard, TypeInternalPassport:
+		return true
+	default:
+		return false
+	}
+}
+
+func isAddressDocument(t string) bool {
+	switch t {
+	case TypeUtilityBill, TypeBankStatement, TypeRentalAgreement, TypePassportRegistration, TypeTemporaryRegistration:
+		return true
+	default:
+		return false
+	}
+}
+
+// DecryptPassportData decrypts credentials by the bot private key and then all shared elements of PassportData. If
+// NonceStore is set, credentials with unknown or already used nonce are rejected by ErrNonce, otherwise don't forget
+// to check that Credentials.Nonce is equal to the nonce of your authorization request.
+func (b Bot) DecryptPassportData(ctx context.Context, pk *rsa.PrivateKey, pd *PassportData) (*DecryptedPassportData, error) {
+	if pd == nil {
+		return nil, ErrPassportData
 	}
 
-	body, err := sv.Data.decrypt(epe.Data)
+	c, err := pd.Credentials.Decrypt(pk)
 	if err != nil {
 		return nil, err
 	}
 
-	var pd PersonalDetails
-	if err = json.ConfigFastest.Unmarshal(body, &pd); err != nil {
-		return nil, Error{Description: err.Error()}
+	if b.nonces != nil {
+		if err = b.nonces.Use(ctx, c.Nonce); err != nil {
+			return nil, err
+		}
+	}
+
+	result := DecryptedPassportData{Credentials: c, Elements: pd.Data}
+
+	for _, epe := range pd.Data {
+		if epe == nil {
+			continue
+		}
+
+		sv := c.SecureData.value(epe.Type)
+
+		switch epe.Type {
+		case TypePersonalDetails:
+			result.PersonalDetails, err = epe.DecryptPersonalDetails(sv)
+		case TypeAddress:
+			result.Address, err = epe.DecryptResidentialAddress(sv)
+		case TypePassport:
+			result.Passport, err = b.DecryptPassport(ctx, epe, sv)
+		case TypeInternalPassport:
+			result.InternalPassport, err = b.DecryptInternalPassport(ctx, epe, sv)
+		case TypeDriverLicense:
+			result.DriverLicense, err = b.DecryptDriverLicense(ctx, epe, sv)
+		case TypeIdentityCard:
+			result.IdentityCard, err = b.DecryptIdentityCard(ctx, epe, sv)
+		case TypeUtilityBill:
+			result.UtilityBill, err = b.DecryptPassportDocument(ctx, epe, sv)
+		case TypeBankStatement:
+			result.BankStatement, err = b.DecryptPassportDocument(ctx, epe, sv)
+		case TypeRentalAgreement:
+			result.RentalAgreement, err = b.DecryptPassportDocument(ctx, epe, sv)
+		case TypePassportRegistration:
+			result.PassportRegistration, err = b.DecryptPassportDocument(ctx, epe, sv)
+		case TypeTemporaryRegistration:
+			result.TemporaryRegistration, err = b.DecryptPassportDocument(ctx, epe, sv)
+		case TypePhoneNumber:
+			result.PhoneNumber = epe.PhoneNumber
+		case TypeEmail:
+			result.Email = epe.Email
+		}
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return &pd, err
+	return &result, nil
 }
 
-func (epe *EncryptedPassportElement) DecryptPassport(sv *SecureValue, b *Bot) (*IDDocumentData, []byte, []byte, [][]byte, error) {
-	if !epe.IsPassport() || !sv.HasData() || !sv.HasFrontSide() {
-		return nil, nil, nil, nil, nil
+// DecryptFile downloads the Telegram Passport file and decrypts it by the file credentials.
+func (b Bot) DecryptFile(ctx context.Context, pf *PassportFile, fc *FileCredentials) ([]byte, error) {
+	if pf == nil || fc == nil {
+		return nil, ErrNoCredentials
 	}
 
-	body, err := sv.Data.decrypt(epe.Data)
+	r, err := b.DownloadFile(ctx, &File{FileID: pf.FileID, FileUniqueID: pf.FileUniqueID, FileSize: pf.FileSize})
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, err
 	}
+	defer r.Close()
 
-	var idd IDDocumentData
-	if err = b.marshler.Unmarshal(body, &idd); err != nil {
-		return nil, nil, nil, nil, err
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
 	}
 
-	fs, err := b.DecryptFile(epe.FrontSide, sv.FrontSide)
-	if err != nil {
-		return &idd, nil, nil, nil, err
+	return fc.Decrypt(data)
+}
+
+// DecryptPassport decrypts data and files of the passport element.
+func (b Bot) DecryptPassport(ctx context.Context, epe *EncryptedPassportElement, sv *SecureValue) (*IDDocument, error) {
+	if !epe.IsPassport() {
+		return nil, ErrPassportType
 	}
 
-	var s []byte
+	return b.decryptIDDocument(ctx, epe, sv)
+}
 
-	if sv.HasSelfie() {
-		if s, err = b.DecryptFile(epe.Selfie, sv.Selfie); err != nil {
-			return &idd, fs, nil, nil, err
-		}
+// DecryptInternalPassport decrypts data and files of the internal passport element.
+func (b Bot) DecryptInternalPassport(ctx context.Context, epe *EncryptedPassportElement, sv *SecureValue) (*IDDocument, error) {
+	if !epe.IsInternalPassport() {
+		return nil, ErrPassportType
 	}
 
-	t := make([][]byte, len(sv.Translation))
+	return b.decryptIDDocument(ctx, epe, sv)
+}
 
-	if sv.HasTranslation() {
-		for i := range t {
-			if t[i], err = b.DecryptFile(epe.Translation[i], sv.Translation[i]); err != nil {
-				return &idd, fs, s, nil, err
-			}
-		}
+// DecryptDriverLicense decrypts data and files of the driver license element.
+func (b Bot) DecryptDriverLicense(ctx context.Context, epe *EncryptedPassportElement, sv *SecureValue) (*IDDocument, error) {
+	if !epe.IsDriverLicense() {
+		return nil, ErrPassportType
 	}
 
-	return &idd, fs, s, t, nil
+	return b.decryptIDDocument(ctx, epe, sv)
 }
 
-func (epe *EncryptedPassportElement) DecryptInternalPassport(sv *SecureValue, b *Bot) (*IDDocumentData, []byte, []byte, [][]byte, error) {
-	if !epe.IsInternalPassport() || !sv.HasData() || !sv.HasFrontSide() {
-		return nil, nil, nil, nil, nil
+// DecryptIdentityCard decrypts data and files of the identity card element.
+func (b Bot) DecryptIdentityCard(ctx context.Context, epe *EncryptedPassportElement, sv *SecureValue) (*IDDocument, error) {
+	if !epe.IsIdentityCard() {
+		return nil, ErrPassportType
 	}
 
-	body, err := sv.Data.decrypt(epe.Data)
-	if err != nil {
-		return nil, nil, nil, nil, err
+	return b.decryptIDDocument(ctx, epe, sv)
+}
+
+// DecryptPassportDocument decrypts files of the utility bill, bank statement, rental agreement, passport registration
+// or temporary registration element.
+func (b Bot) DecryptPassportDocument(ctx context.Context, epe *EncryptedPassportElement, sv *SecureValue) (*PassportDocument, error) {
+	if !epe.IsUtilityBill() && !epe.IsBankStatement() && !epe.IsRentalAgreement() &&
+		!epe.IsPassportRegistration() && !epe.IsTemporaryRegistration() {
+		return nil, ErrPassportType
 	}
 
-	var idd IDDocumentData
-	if err = b.marshler.Unmarshal(body, &idd); err != nil {
-		return nil, nil, nil, nil, err
+	if !sv.HasFiles() || len(sv.Files) != len(epe.Files) {
+		return nil, ErrNoCredentials
 	}
 
-	fs, err := b.DecryptFile(epe.FrontSide, sv.FrontSide)
+	var (
+		doc PassportDocument
+		err error
+	)
+
+	if doc.Files, err = b.decryptFiles(ctx, epe.Files, sv.Files); err != nil {
+		return nil, err
+	}
+
+	if doc.Translation, err = b.decryptFiles(ctx, epe.Translation, sv.Translation); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+func (b Bot) decryptIDDocument(ctx context.Context, epe *EncryptedPassportElement, sv *SecureValue) (*IDDocument, error) {
+	idd, err := epe.DecryptIDDocumentData(sv)
 	if err != nil {
-		return &idd, nil, nil, nil, err
+		return nil, err
 	}
 
-	var s []byte
+	doc := IDDocument{Data: idd}
 
-	if sv.HasSelfie() {
-		if s, err = b.DecryptFile(epe.Selfie, sv.Selfie); err != nil {
-			return &idd, fs, nil, nil, err
+	if doc.FrontSide, err = b.DecryptFile(ctx, epe.FrontSide, sv.FrontSide); err != nil {
+		return nil, err
+	}
+
+	if epe.ReverseSide != nil {
+		if doc.ReverseSide, err = b.DecryptFile(ctx, epe.ReverseSide, sv.ReverseSide); err != nil {
+			return nil, err
 		}
 	}
 
-	t := make([][]byte, len(sv.Translation))
+	if epe.Selfie != nil {
+		if doc.Selfie, err = b.DecryptFile(ctx, epe.Selfie, sv.Selfie); err != nil {
+			return nil, err
+		}
+	}
 
-	if sv.HasTranslation() {
-		for i := range t {
-			if t[i], err = b.DecryptFile(epe.Translation[i], sv.Translation[i]); err != nil {
-				return &idd, fs, s, nil, err
-			}
+	if doc.Translation, err = b.decryptFiles(ctx, epe.Translation, sv.Translation); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+func (b Bot) decryptFiles(ctx context.Context, files []*PassportFile, fcs []*FileCredentials) ([][]byte, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	if len(fcs) != len(files) {
+		return nil, ErrNoCredentials
+	}
+
+	result := make([][]byte, len(files))
+
+	for i := range files {
+		data, err := b.DecryptFile(ctx, files[i], fcs[i])
+		if err != nil {
+			return nil, err
 		}
+
+		result[i] = data
 	}
 
-	return &idd, fs, s, t, nil
+	return result, nil
 }
 
-func (epe *EncryptedPassportElement) DecryptDriverLicense(sv *SecureValue, b *Bot) (*IDDocumentData, []byte, []byte, []byte, [][]byte, error) {
-	if !epe.IsDriverLicense() || !sv.HasData() || !sv.HasFrontSide() || !sv.HasReverseSide() {
-		return nil, nil, nil, nil, nil, nil
+// Decrypt decrypts the credentials secret by the bot private key and then the credentials data by this secret.
+func (ec *EncryptedCredentials) Decrypt(pk *rsa.PrivateKey) (*Credentials, error) {
+	if ec == nil || pk == nil {
+		return nil, ErrNoCredentials
 	}
 
-	body, err := sv.Data.decrypt(epe.Data)
+	secret, err := decodeField(ec.Secret)
 	if err != nil {
-		return nil, nil, nil, nil, nil, err
+		return nil, err
 	}
 
-	var idd IDDocumentData
-	if err = b.marshler.Unmarshal(body, &idd); err != nil {
-		return nil, nil, nil, nil, nil, err
+	// Decrypt the credentials secret using your private key
+	if secret, err = rsa.DecryptOAEP(sha1.New(), rand.Reader, pk, secret, nil); err != nil { //nolint: gosec
+		return nil, err
 	}
 
-	fs, err := b.DecryptFile(epe.FrontSide, sv.FrontSide)
+	hash, err := decodeField(ec.Hash)
 	if err != nil {
-		return &idd, nil, nil, nil, nil, err
+		return nil, err
 	}
 
-	rs, err := b.DecryptFile(epe.ReverseSide, sv.ReverseSide)
+	data, err := decodeField(ec.Data)
 	if err != nil {
-		return &idd, nil, nil, nil, nil, err
+		return nil, err
 	}
 
-	var s []byte
+	if data, err = decrypt(secret, hash, data); err != nil {
+		return nil, err
+	}
 
-	if sv.HasSelfie() {
-		if s, err = b.DecryptFile(epe.Selfie, sv.Selfie); err != nil {
-			return &idd, fs, rs, nil, nil, err
-		}
+	var c Credentials
+	if err = json.ConfigFastest.Unmarshal(data, &c); err != nil {
+		return nil, err
 	}
 
-	t := make([][]byte, len(sv.Translation))
+	if c.SecureData == nil {
+		c.SecureData = new(SecureData)
+	}
 
-	if sv.HasTranslation() {
-		for i := range t {
-			if t[i], err = b.DecryptFile(epe.Translation[i], sv.Translation[i]); err != nil {
-				return &idd, fs, rs, s, nil, err
-			}
-		}
+	return &c, nil
+}
+
+// DecryptPersonalDetails decrypts data of the personal details element.
+func (epe *EncryptedPassportElement) DecryptPersonalDetails(sv *SecureValue) (*PersonalDetails, error) {
+	if !epe.IsPersonalDetails() {
+		return nil, ErrPassportType
+	}
+
+	var pd PersonalDetails
+	if err := epe.decryptData(sv, &pd); err != nil {
+		return nil, err
+	}
+
+	return &pd, nil
+}
+
+// DecryptResidentialAddress decrypts data of the address element.
+func (epe *EncryptedPassportElement) DecryptResidentialAddress(sv *SecureValue) (*ResidentialAddress, error) {
+	if !epe.IsAddress() {
+		return nil, ErrPassportType
+	}
+
+	var ra ResidentialAddress
+	if err := epe.decryptData(sv, &ra); err != nil {
+		return nil, err
+	}
+
+	return &ra, nil
+}
+
+// DecryptIDDocumentData decrypts data of the passport, internal passport, driver license or identity card element.
+func (epe *EncryptedPassportElement) DecryptIDDocumentData(sv *SecureValue) (*IDDocumentData, error) {
+	if !epe.IsPassport() && !epe.IsInternalPassport() && !epe.IsDriverLicense() && !epe.IsIdentityCard() {
+		return nil, ErrPassportType
 	}
 
-	return &idd, fs, rs, s, t, nil
+	var idd IDDocumentData
+	if err := epe.decryptData(sv, &idd); err != nil {
+		return nil, err
+	}
+
+	return &idd, nil
+}
+
+func (epe *EncryptedPassportElement) decryptData(sv *SecureValue, dst interface{}) error {
+	if !sv.HasData() {
+		return ErrNoCredentials
+	}
+
+	data, err := sv.Data.decrypt(epe.Data)
+	if err != nil {
+		return err
+	}
+
+	return json.ConfigFastest.Unmarshal(data, dst)
 }
 
+// IsAddress checks that the element contains residential address.
 func (epe *EncryptedPassportElement) IsAddress() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeAddress)
 }
 
+// IsBankStatement checks that the element contains bank statement.
 func (epe *EncryptedPassportElement) IsBankStatement() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeBankStatement)
 }
 
+// IsDriverLicense checks that the element contains driver license.
 func (epe *EncryptedPassportElement) IsDriverLicense() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeDriverLicense)
 }
 
+// IsEmail checks that the element contains email.
 func (epe *EncryptedPassportElement) IsEmail() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeEmail)
 }
 
+// IsIdentityCard checks that the element contains identity card.
 func (epe *EncryptedPassportElement) IsIdentityCard() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeIdentityCard)
 }
 
+// IsInternalPassport checks that the element contains internal passport.
 func (epe *EncryptedPassportElement) IsInternalPassport() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeInternalPassport)
 }
 
+// IsPassport checks that the element contains passport.
 func (epe *EncryptedPassportElement) IsPassport() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypePassport)
 }
 
+// IsPassportRegistration checks that the element contains passport registration.
 func (epe *EncryptedPassportElement) IsPassportRegistration() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypePassportRegistration)
 }
 
+// IsPersonalDetails checks that the element contains personal details.
 func (epe *EncryptedPassportElement) IsPersonalDetails() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypePersonalDetails)
 }
 
+// IsPhoneNumber checks that the element contains phone number.
 func (epe *EncryptedPassportElement) IsPhoneNumber() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypePhoneNumber)
 }
 
+// IsRentalAgreement checks that the element contains rental agreement.
 func (epe *EncryptedPassportElement) IsRentalAgreement() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeRentalAgreement)
 }
 
+// IsTemporaryRegistration checks that the element contains temporary registration.
 func (epe *EncryptedPassportElement) IsTemporaryRegistration() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeTemporaryRegistration)
 }
 
+// IsUtilityBill checks that the element contains utility bill.
 func (epe *EncryptedPassportElement) IsUtilityBill() bool {
 	return epe != nil && strings.EqualFold(epe.Type, TypeUtilityBill)
 }
 
-func (idd *IDDocumentData) ExpiryTime() *time.Time {
-	if idd == nil || idd.ExpiryDate == "" {
-		return nil
+// Decrypt decrypts the content of downloaded Telegram Passport file.
+func (fc *FileCredentials) Decrypt(data []byte) ([]byte, error) {
+	if fc == nil {
+		return nil, ErrNoCredentials
 	}
 
-	et, err := time.Parse("02.01.2006", idd.ExpiryDate)
+	secret, err := decodeField(fc.Secret)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	return &et
+	hash, err := decodeField(fc.FileHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return decrypt(secret, hash, data)
 }
 
-func decrypt(pk *rsa.PrivateKey, s, h, d string) (obj []byte, err error) {
-	// Note that all base64-encoded fields should be decoded before use.
-	secret, err := decodeField(s)
+func (dc *DataCredentials) decrypt(d string) ([]byte, error) {
+	secret, err := decodeField(dc.Secret)
 	if err != nil {
 		return nil, err
 	}
 
-	hash, err := decodeField(h)
+	hash, err := decodeField(dc.DataHash)
 	if err != nil {
 		return nil, err
 	}
@@ -866,109 +1325,198 @@ func decrypt(pk *rsa.PrivateKey, s, h, d string) (obj []byte, err error) {
 		return nil, err
 	}
 
-	if pk != nil {
-		// Decrypt the credentials secret (secret field in EncryptedCredentials)
-		// using your private key
-		if secret, err = decryptSecret(pk, secret); err != nil {
-			return nil, err
-		}
+	return decrypt(secret, hash, data)
+}
+
+// value returns credentials of element with provided type.
+func (sd *SecureData) value(t string) *SecureValue {
+	if sd == nil {
+		return nil
 	}
 
-	// Use this secret and the credentials hash (hash field in
-	// EncryptedCredentials) to calculate credentials_key and credentials_iv
-	key, iv := decryptSecretHash(secret, hash)
+	switch t {
+	case TypePersonalDetails:
+		return sd.PersonalDetails
+	case TypePassport:
+		return sd.Passport
+	case TypeInternalPassport:
+		return sd.InternalPassport
+	case TypeDriverLicense:
+		return sd.DriverLicense
+	case TypeIdentityCard:
+		return sd.IdentityCard
+	case TypeAddress:
+		return sd.Address
+	case TypeUtilityBill:
+		return sd.UtilityBill
+	case TypeBankStatement:
+		return sd.BankStatement
+	case TypeRentalAgreement:
+		return sd.RentalAgreement
+	case TypePassportRegistration:
+		return sd.PassportRegistration
+	case TypeTemporaryRegistration:
+		return sd.TemporaryRegistration
+	default:
+		return nil
+	}
+}
 
-	// Decrypt the credentials data (data field in EncryptedCredentials) by
-	// AES256-CBC using these credentials_key and credentials_iv.
-	if data, err = decryptData(key, iv, data); err != nil {
-		return nil, err
+// ExpiryTime returns the parsed date of document expiry, if any.
+func (idd *IDDocumentData) ExpiryTime() *time.Time {
+	if idd == nil || idd.ExpiryDate == "" {
+		return nil
 	}
 
-	// IMPORTANT: At this step, make sure that the credentials hash is equal
-	// to SHA256(credentials_data)
-	if !match(hash, data) {
-		return nil, ErrNotEqual
+	et, err := time.Parse("02.01.2006", idd.ExpiryDate)
+	if err != nil {
+		return nil
 	}
 
-	// Credentials data is padded with 32 to 255 random padding bytes to make
-	// its length divisible by 16 bytes. The first byte contains the length
-	// of this padding (including this byte). Remove the padding to get the
-	// data.
-	return data[int(data[0]):], nil
+	return &et
 }
 
-func decodeField(rawField string) (field []byte, err error) {
-	return base64.StdEncoding.DecodeString(rawField)
+// BirthTime returns the parsed date of birth, if any.
+func (pd *PersonalDetails) BirthTime() *time.Time {
+	if pd == nil || pd.BirthDate == "" {
+		return nil
+	}
+
+	bt, err := time.Parse("02.01.2006", pd.BirthDate)
+	if err != nil {
+		return nil
+	}
+
+	return &bt
 }
 
-func decryptSecret(pk *rsa.PrivateKey, s []byte) (secret []byte, err error) {
-	return rsa.DecryptOAEP(sha1.New(), rand.Reader, pk, s, nil) //nolint: gosec
+// FullName returns the first and last names of the user.
+func (pd PersonalDetails) FullName() string { return pd.FirstName + " " + pd.LastName }
+
+// FullNameNative returns the first and last names of the user in the language of the user's country of residence.
+func (pd PersonalDetails) FullNameNative() string {
+	return pd.FirstNameNative + " " + pd.LastNameNative
 }
 
-func decryptSecretHash(s, h []byte) (key, iv []byte) {
-	var err error
+// HasData checks that the value contains data credentials.
+func (sv *SecureValue) HasData() bool { return sv != nil && sv.Data != nil }
 
-	hash := sha512.New()
-	if _, err = hash.Write(s); err != nil {
-		return
-	}
+// HasFiles checks that the value contains files credentials.
+func (sv *SecureValue) HasFiles() bool { return sv != nil && len(sv.Files) > 0 }
 
-	if _, err = hash.Write(h); err != nil {
-		return
-	}
+// HasFrontSide checks that the value contains front side credentials.
+func (sv *SecureValue) HasFrontSide() bool { return sv != nil && sv.FrontSide != nil }
 
-	sh := hash.Sum(nil)
+// HasReverseSide checks that the value contains reverse side credentials.
+func (sv *SecureValue) HasReverseSide() bool { return sv != nil && sv.ReverseSide != nil }
 
-	return sh[0:32], sh[32 : 32+16]
+// HasSelfie checks that the value contains selfie credentials.
+func (sv *SecureValue) HasSelfie() bool { return sv != nil && sv.Selfie != nil }
+
+// HasTranslation checks that the value contains translation credentials.
+func (sv *SecureValue) HasTranslation() bool { return sv != nil && len(sv.Translation) > 0 }
+
+// DataHash returns the base64-encoded hash of data for PassportElementErrorDataField.
+func (sv *SecureValue) DataHash() string {
+	if !sv.HasData() {
+		return ""
+	}
+
+	return sv.Data.DataHash
 }
 
-func match(h, d []byte) bool {
-	dh := sha256.New()
-	if _, err := dh.Write(d); err != nil {
-		return false
+// FrontSideHash returns the base64-encoded hash of front side file for PassportElementErrorFrontSide.
+func (sv *SecureValue) FrontSideHash() string {
+	if !sv.HasFrontSide() {
+		return ""
 	}
 
-	return bytes.EqualFold(h, dh.Sum(nil))
+	return sv.FrontSide.FileHash
 }
 
-func decryptData(key, iv, data []byte) (buf []byte, err error) {
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return
+// ReverseSideHash returns the base64-encoded hash of reverse side file for PassportElementErrorReverseSide.
+func (sv *SecureValue) ReverseSideHash() string {
+	if !sv.HasReverseSide() {
+		return ""
 	}
 
-	buf = make([]byte, len(data))
-	cipher.NewCBCDecrypter(block, iv).CryptBlocks(buf, data)
+	return sv.ReverseSide.FileHash
+}
+
+// SelfieHash returns the base64-encoded hash of selfie file for PassportElementErrorSelfie.
+func (sv *SecureValue) SelfieHash() string {
+	if !sv.HasSelfie() {
+		return ""
+	}
 
-	return
+	return sv.Selfie.FileHash
 }
 
-func (pd *PersonalDetails) BirthTime() *time.Time {
-	if pd == nil || pd.BirthDate == "" {
+// FileHashes returns the base64-encoded hashes of files for PassportElementErrorFile and
+// PassportElementErrorFiles.
+func (sv *SecureValue) FileHashes() []string {
+	if !sv.HasFiles() {
 		return nil
 	}
 
-	bt, err := time.Parse("02.01.2006", pd.BirthDate)
-	if err != nil {
+	return fileHashes(sv.Files)
+}
+
+// TranslationHashes returns the base64-encoded hashes of translation files for
+// PassportElementErrorTranslationFile and PassportElementErrorTranslationFiles.
+func (sv *SecureValue) TranslationHashes() []string {
+	if !sv.HasTranslation() {
 		return nil
 	}
 
-	return &bt
+	return fileHashes(sv.Translation)
 }
 
-func (pd PersonalDetails) FullName() string { return pd.FirstName + " " + pd.LastName }
+func fileHashes(fcs []*FileCredentials) []string {
+	hashes := make([]string, 0, len(fcs))
 
-func (pd PersonalDetails) FullNameNative() string { return pd.FirstNameNative + " " + pd.LastNameNative }
+	for i := range fcs {
+		if fcs[i] != nil {
+			hashes = append(hashes, fcs[i].FileHash)
+		}
+	}
 
-func (sv *SecureValue) HasData() bool { return sv != nil && sv.Data != nil }
+	return hashes
+}
 
-func (sv *SecureValue) HasFiles() bool { return sv != nil && len(sv.Files) > 0 }
+// decrypt decrypts data by AES256-CBC with key and iv calculated from secret and hash, checks that hash is equal to
+// SHA256 of decrypted data and removes padding.
+func decrypt(secret, hash, data []byte) ([]byte, error) {
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, ErrPassportData
+	}
 
-func (sv *SecureValue) HasFrontSide() bool { return sv != nil && sv.FrontSide != nil }
+	// Use the secret and the hash to calculate key and iv
+	sh := sha512.Sum512(append(append(make([]byte, 0, len(secret)+len(hash)), secret...), hash...))
 
-func (sv *SecureValue) HasReverseSide() bool { return sv != nil && sv.ReverseSide != nil }
+	block, err := aes.NewCipher(sh[0:32])
+	if err != nil {
+		return nil, err
+	}
 
-func (sv *SecureValue) HasSelfie() bool { return sv != nil && sv.Selfie != nil }
+	buf := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, sh[32:32+aes.BlockSize]).CryptBlocks(buf, data)
 
-func (sv *SecureValue) HasTranslation() bool { return sv != nil && len(sv.Translation) > 0 }
-*/
+	// IMPORTANT: make sure that the hash is equal to SHA256 of decrypted data
+	if dh := sha256.Sum256(buf); !bytes.Equal(hash, dh[:]) {
+		return nil, ErrNotEqual
+	}
+
+	// Data is padded with 32 to 255 random padding bytes to make its length divisible by 16 bytes. The first byte
+	// contains the length of this padding (including this byte).
+	if padding := int(buf[0]); padding < 32 || padding > len(buf) {
+		return nil, ErrPassportData
+	}
+
+	return buf[int(buf[0]):], nil
+}
+
+// decodeField decodes base64-encoded field.
+func decodeField(rawField string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(rawField)
+}
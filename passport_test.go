@@ -0,0 +1,284 @@
+package telegram
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint: gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+// encryptPassport encrypts data as Telegram does and returns the encrypted data with secret and hash of it.
+func encryptPassport(t *testing.T, data []byte, padding int) (encrypted, secret, hash []byte) {
+	t.Helper()
+
+	if padding == 0 {
+		padding = 32 + (aes.BlockSize-(len(data)+32)%aes.BlockSize)%aes.BlockSize
+	}
+
+	buf := make([]byte, padding+len(data))
+	_, err := rand.Read(buf[:padding])
+	assert.NoError(t, err)
+	buf[0] = byte(padding)
+	copy(buf[padding:], data)
+
+	secret = make([]byte, 32)
+	_, err = rand.Read(secret)
+	assert.NoError(t, err)
+
+	dh := sha256.Sum256(buf)
+	hash = dh[:]
+	sh := sha512.Sum512(append(append([]byte{}, secret...), hash...))
+
+	block, err := aes.NewCipher(sh[0:32])
+	assert.NoError(t, err)
+
+	encrypted = make([]byte, len(buf))
+	cipher.NewCBCEncrypter(block, sh[32:48]).CryptBlocks(encrypted, buf)
+
+	return encrypted, secret, hash
+}
+
+func encodeField(src []byte) string { return base64.StdEncoding.EncodeToString(src) }
+
+func TestDecrypt(t *testing.T) {
+	data := []byte(`{"document_no":"42","expiry_date":"01.01.2030"}`)
+
+	t.Run("valid", func(t *testing.T) {
+		encrypted, secret, hash := encryptPassport(t, data, 0)
+
+		result, err := decrypt(secret, hash, encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, data, result)
+	})
+	t.Run("hash", func(t *testing.T) {
+		encrypted, secret, hash := encryptPassport(t, data, 0)
+		hash[0]++
+
+		_, err := decrypt(secret, hash, encrypted)
+		assert.Equal(t, ErrNotEqual, err)
+	})
+	t.Run("length", func(t *testing.T) {
+		encrypted, secret, hash := encryptPassport(t, data, 0)
+
+		_, err := decrypt(secret, hash, encrypted[1:])
+		assert.Equal(t, ErrPassportData, err)
+	})
+	t.Run("padding", func(t *testing.T) {
+		encrypted, secret, hash := encryptPassport(t, data[:30], 18)
+
+		_, err := decrypt(secret, hash, encrypted)
+		assert.Equal(t, ErrPassportData, err)
+	})
+}
+
+func TestBotDecryptPassportData(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 1024)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	pdData, pdSecret, pdHash := encryptPassport(t, []byte(`{"first_name":"Maxim","last_name":"Lebedev",`+
+		`"birth_date":"02.01.2006","gender":"male","country_code":"RU","residence_country_code":"RU"}`), 0)
+	docData, docSecret, docHash := encryptPassport(t, []byte(`{"document_no":"42","expiry_date":"01.01.2030"}`), 0)
+	front, frontSecret, frontHash := encryptPassport(t, []byte("front side"), 0)
+	selfie, selfieSecret, selfieHash := encryptPassport(t, []byte("selfie"), 0)
+
+	credentials, err := json.ConfigFastest.Marshal(&Credentials{
+		Nonce: "nonce",
+		SecureData: &SecureData{
+			PersonalDetails: &SecureValue{Data: &DataCredentials{
+				DataHash: encodeField(pdHash), Secret: encodeField(pdSecret),
+			}},
+			Passport: &SecureValue{
+				Data:      &DataCredentials{DataHash: encodeField(docHash), Secret: encodeField(docSecret)},
+				FrontSide: &FileCredentials{FileHash: encodeField(frontHash), Secret: encodeField(frontSecret)},
+				Selfie:    &FileCredentials{FileHash: encodeField(selfieHash), Secret: encodeField(selfieSecret)},
+			},
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	cData, cSecret, cHash := encryptPassport(t, credentials, 0)
+	cSecret, err = rsa.EncryptOAEP(sha1.New(), rand.Reader, &pk.PublicKey, cSecret, nil) //nolint: gosec
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	files := map[string][]byte{"front": front, "selfie": selfie}
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		path := string(ctx.Path())
+
+		switch {
+		case path == "/bot123:abc/getFile":
+			id := json.Get(ctx.PostBody(), "file_id").ToString()
+			ctx.SetBodyString(`{"ok":true,"result":{"file_id":"` + id + `","file_path":"passport/` + id + `.jpg"}}`)
+		case strings.HasPrefix(path, "/file/bot123:abc/passport/"):
+			ctx.SetBody(files[strings.TrimSuffix(strings.TrimPrefix(path, "/file/bot123:abc/passport/"), ".jpg")])
+		default:
+			ctx.SetStatusCode(http.StatusNotFound)
+		}
+	})
+	defer stop()
+
+	pd := &PassportData{
+		Credentials: &EncryptedCredentials{
+			Data: encodeField(cData), Hash: encodeField(cHash), Secret: encodeField(cSecret),
+		},
+		Data: []*EncryptedPassportElement{
+			{Type: TypePersonalDetails, Data: encodeField(pdData)},
+			{
+				Type:      TypePassport,
+				Data:      encodeField(docData),
+				FrontSide: &PassportFile{FileID: "front"},
+				Selfie:    &PassportFile{FileID: "selfie"},
+			},
+			{Type: TypePhoneNumber, PhoneNumber: "79001234567"},
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		result, err := b.DecryptPassportData(context.Background(), pk, pd)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		assert.Equal(t, "nonce", result.Credentials.Nonce)
+		assert.Equal(t, "Maxim Lebedev", result.PersonalDetails.FullName())
+		assert.Equal(t, 2006, result.PersonalDetails.BirthTime().Year())
+		assert.Equal(t, "42", result.Passport.Data.DocumentNo)
+		assert.Equal(t, 2030, result.Passport.Data.ExpiryTime().Year())
+		assert.Equal(t, []byte("front side"), result.Passport.FrontSide)
+		assert.Equal(t, []byte("selfie"), result.Passport.Selfie)
+		assert.Nil(t, result.Passport.ReverseSide)
+		assert.Nil(t, result.Address)
+		assert.Equal(t, "79001234567", result.PhoneNumber)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		other, err := rsa.GenerateKey(rand.Reader, 1024)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		_, err = b.DecryptPassportData(context.Background(), other, pd)
+		assert.Error(t, err)
+	})
+	t.Run("wrong type", func(t *testing.T) {
+		_, err := b.DecryptDriverLicense(context.Background(), pd.Data[1], nil)
+		assert.Equal(t, ErrPassportType, err)
+	})
+	t.Run("tampered file", func(t *testing.T) {
+		files["selfie"] = front
+		defer func() { files["selfie"] = selfie }()
+
+		_, err := b.DecryptPassportData(context.Background(), pk, pd)
+		assert.True(t, xerrors.Is(err, ErrNotEqual))
+	})
+	t.Run("nonce", func(t *testing.T) {
+		store := NewMemoryNonceStore(0)
+		b.SetNonceStore(store)
+		defer b.SetNonceStore(nil)
+
+		_, err := b.DecryptPassportData(context.Background(), pk, pd)
+		assert.Equal(t, ErrNonce, err)
+
+		assert.NoError(t, store.Add(context.Background(), "nonce"))
+
+		_, err = b.DecryptPassportData(context.Background(), pk, pd)
+		assert.NoError(t, err)
+
+		_, err = b.DecryptPassportData(context.Background(), pk, pd)
+		assert.Equal(t, ErrNonce, err)
+	})
+}
+
+func TestPassportScopeValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		scope *PassportScope
+		valid bool
+	}{
+		"valid": {NewPassportScope(
+			&PassportScopeElementOne{Type: TypePersonalDetails, NativeNames: true},
+			&PassportScopeElementOneOfSeveral{OneOf: []*PassportScopeElementOne{
+				{Type: TypePassport}, {Type: TypeIdentityCard},
+			}, Selfie: true},
+			&PassportScopeElementOneOfSeveral{OneOf: []*PassportScopeElementOne{
+				{Type: TypeUtilityBill}, {Type: TypeBankStatement},
+			}, Translation: true},
+			&PassportScopeElementOne{Type: TypePhoneNumber},
+		), true},
+		"empty":   {NewPassportScope(), false},
+		"version": {&PassportScope{Data: []PassportScopeElement{&PassportScopeElementOne{Type: TypeEmail}}}, false},
+		"type":    {NewPassportScope(&PassportScopeElementOne{Type: "selfie"}), false},
+		"duplicate": {NewPassportScope(
+			&PassportScopeElementOne{Type: TypePassport},
+			&PassportScopeElementOneOfSeveral{OneOf: []*PassportScopeElementOne{
+				{Type: TypePassport}, {Type: TypeDriverLicense},
+			}},
+		), false},
+		"one of mixed": {NewPassportScope(&PassportScopeElementOneOfSeveral{OneOf: []*PassportScopeElementOne{
+			{Type: TypePassport}, {Type: TypeUtilityBill},
+		}}), false},
+		"one of not documents": {NewPassportScope(&PassportScopeElementOneOfSeveral{OneOf: []*PassportScopeElementOne{
+			{Type: TypePhoneNumber}, {Type: TypeEmail},
+		}}), false},
+		"selfie": {NewPassportScope(&PassportScopeElementOne{Type: TypeAddress, Selfie: true}), false},
+		"translation": {NewPassportScope(
+			&PassportScopeElementOne{Type: TypePersonalDetails, Translation: true},
+		), false},
+		"native names": {NewPassportScope(&PassportScopeElementOne{Type: TypePassport, NativeNames: true}), false},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			err := tc.scope.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, xerrors.Is(err, ErrPassportScope))
+			}
+		})
+	}
+}
+
+func TestBotNewPassportAuth(t *testing.T) {
+	store := NewMemoryNonceStore(0)
+	b := Bot{AccessToken: "123:abc", nonces: store}
+	scope := NewPassportScope(&PassportScopeElementOne{Type: TypeEmail})
+
+	auth, err := b.NewPassportAuth(context.Background(), scope, "-----BEGIN PUBLIC KEY-----")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 123, auth.BotID)
+	assert.NotEmpty(t, auth.Nonce)
+	assert.NoError(t, store.Use(context.Background(), auth.Nonce))
+
+	link, err := auth.URL()
+	assert.NoError(t, err)
+	assert.Equal(t, "tg://resolve?domain=telegrampassport&bot_id=123&nonce="+auth.Nonce+
+		"&public_key=-----BEGIN+PUBLIC+KEY-----&scope=%7B%22data%22%3A%5B%7B%22type%22%3A%22email%22%7D%5D%2C%22v%22%3A1%7D",
+		link)
+
+	options, err := auth.Options()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"bot_id":123,"scope":{"data":[{"type":"email"}],"v":1},`+
+		`"public_key":"-----BEGIN PUBLIC KEY-----","nonce":"`+auth.Nonce+`"}`, string(options))
+
+	_, err = b.NewPassportAuth(context.Background(), NewPassportScope(), "")
+	assert.True(t, xerrors.Is(err, ErrPassportScope))
+}
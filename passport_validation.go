@@ -0,0 +1,267 @@
+package telegram
+
+import "time"
+
+// PassportRule checks decrypted Telegram Passport data and returns errors of elements which does not satisfy it.
+type PassportRule func(d *DecryptedPassportData) []PassportElementError
+
+// Validate checks data by all rules and returns found errors, which can be sent by SetPassportDataErrors as is.
+func (d *DecryptedPassportData) Validate(rules ...PassportRule) []PassportElementError {
+	errs := make([]PassportElementError, 0)
+	if d == nil {
+		return errs
+	}
+
+	for i := range rules {
+		for _, err := range rules[i](d) {
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errs
+}
+
+// NotExpired creates a rule which requires expiry date of identity documents in the future, if it's provided.
+func NotExpired(message string) PassportRule {
+	return func(d *DecryptedPassportData) []PassportElementError {
+		now := time.Now()
+		errs := make([]PassportElementError, 0)
+
+		for _, t := range identityDocumentTypes() {
+			doc := d.identityDocument(t)
+			if doc == nil || doc.Data == nil || doc.Data.ExpiryDate == "" {
+				continue
+			}
+
+			if et := doc.Data.ExpiryTime(); et == nil || !et.After(now) {
+				errs = append(errs, d.DataFieldError(t, "expiry_date", message))
+			}
+		}
+
+		return errs
+	}
+}
+
+// MinimumAge creates a rule which requires the user to be at least years old by date of birth in personal details.
+func MinimumAge(years int, message string) PassportRule {
+	return func(d *DecryptedPassportData) []PassportElementError {
+		if d.PersonalDetails == nil {
+			return nil
+		}
+
+		if bt := d.PersonalDetails.BirthTime(); bt != nil && !bt.AddDate(years, 0, 0).After(time.Now()) {
+			return nil
+		}
+
+		return []PassportElementError{d.DataFieldError(TypePersonalDetails, "birth_date", message)}
+	}
+}
+
+// SelfieRequired creates a rule which requires selfie with identity documents of provided types or with all of them
+// if types are not provided.
+func SelfieRequired(message string, types ...string) PassportRule {
+	return func(d *DecryptedPassportData) []PassportElementError {
+		errs := make([]PassportElementError, 0)
+
+		for _, t := range identityDocumentTypes() {
+			if doc := d.identityDocument(t); doc != nil && doc.Selfie == nil && hasType(types, t) {
+				errs = append(errs, d.UnspecifiedError(t, message))
+			}
+		}
+
+		return errs
+	}
+}
+
+// TranslationRequired creates a rule which requires translation of documents of provided types or of all documents
+// if types are not provided.
+func TranslationRequired(message string, types ...string) PassportRule {
+	return func(d *DecryptedPassportData) []PassportElementError {
+		errs := make([]PassportElementError, 0)
+
+		for _, t := range identityDocumentTypes() {
+			if doc := d.identityDocument(t); doc != nil && len(doc.Translation) == 0 && hasType(types, t) {
+				errs = append(errs, d.UnspecifiedError(t, message))
+			}
+		}
+
+		for _, t := range documentTypes() {
+			if doc := d.document(t); doc != nil && len(doc.Translation) == 0 && hasType(types, t) {
+				errs = append(errs, d.UnspecifiedError(t, message))
+			}
+		}
+
+		return errs
+	}
+}
+
+// DataFieldError creates an error of data field in element of provided type. It returns nil if element has no data.
+func (d *DecryptedPassportData) DataFieldError(t, field, message string) PassportElementError {
+	hash := d.value(t).DataHash()
+	if hash == "" {
+		return nil
+	}
+
+	return &PassportElementErrorDataField{
+		Source: SourceData, Type: t, FieldName: field, DataHash: hash, Message: message,
+	}
+}
+
+// FrontSideError creates an error of front side in element of provided type. It returns nil if element has no front
+// side.
+func (d *DecryptedPassportData) FrontSideError(t, message string) PassportElementError {
+	hash := d.value(t).FrontSideHash()
+	if hash == "" {
+		return nil
+	}
+
+	return &PassportElementErrorFrontSide{Source: SourceFrontSide, Type: t, FileHash: hash, Message: message}
+}
+
+// ReverseSideError creates an error of reverse side in element of provided type. It returns nil if element has no
+// reverse side.
+func (d *DecryptedPassportData) ReverseSideError(t, message string) PassportElementError {
+	hash := d.value(t).ReverseSideHash()
+	if hash == "" {
+		return nil
+	}
+
+	return &PassportElementErrorReverseSide{Source: SourceReverseSide, Type: t, FileHash: hash, Message: message}
+}
+
+// SelfieError creates an error of selfie in element of provided type. It returns nil if element has no selfie.
+func (d *DecryptedPassportData) SelfieError(t, message string) PassportElementError {
+	hash := d.value(t).SelfieHash()
+	if hash == "" {
+		return nil
+	}
+
+	return &PassportElementErrorSelfie{Source: SourceSelfie, Type: t, FileHash: hash, Message: message}
+}
+
+// FileError creates an error of i-th file in element of provided type. It returns nil if element has no such file.
+func (d *DecryptedPassportData) FileError(t string, i int, message string) PassportElementError {
+	hashes := d.value(t).FileHashes()
+	if i < 0 || i >= len(hashes) {
+		return nil
+	}
+
+	return &PassportElementErrorFile{Source: SourceFile, Type: t, FileHash: hashes[i], Message: message}
+}
+
+// FilesError creates an error of all files in element of provided type. It returns nil if element has no files.
+func (d *DecryptedPassportData) FilesError(t, message string) PassportElementError {
+	hashes := d.value(t).FileHashes()
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	return &PassportElementErrorFiles{Source: SourceFiles, Type: t, FileHashes: hashes, Message: message}
+}
+
+// TranslationFileError creates an error of i-th translation file in element of provided type. It returns nil if
+// element has no such file.
+func (d *DecryptedPassportData) TranslationFileError(t string, i int, message string) PassportElementError {
+	hashes := d.value(t).TranslationHashes()
+	if i < 0 || i >= len(hashes) {
+		return nil
+	}
+
+	return &PassportElementErrorTranslationFile{
+		Source: SourceTranslationFile, Type: t, FileHash: hashes[i], Message: message,
+	}
+}
+
+// TranslationFilesError creates an error of all translation files in element of provided type. It returns nil if
+// element has no translation.
+func (d *DecryptedPassportData) TranslationFilesError(t, message string) PassportElementError {
+	hashes := d.value(t).TranslationHashes()
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	return &PassportElementErrorTranslationFiles{
+		Source: SourceTranslationFiles, Type: t, FileHashes: hashes, Message: message,
+	}
+}
+
+// UnspecifiedError creates an error of element of provided type in an unspecified place. It returns nil if element
+// was not shared.
+func (d *DecryptedPassportData) UnspecifiedError(t, message string) PassportElementError {
+	for _, epe := range d.Elements {
+		if epe != nil && epe.Type == t {
+			return &PassportElementErrorUnspecified{
+				Source: SourceUnspecified, Type: t, ElementHash: epe.Hash, Message: message,
+			}
+		}
+	}
+
+	return nil
+}
+
+// value returns credentials of element with provided type.
+func (d *DecryptedPassportData) value(t string) *SecureValue {
+	if d == nil || d.Credentials == nil {
+		return nil
+	}
+
+	return d.Credentials.SecureData.value(t)
+}
+
+func (d *DecryptedPassportData) identityDocument(t string) *IDDocument {
+	switch t {
+	case TypePassport:
+		return d.Passport
+	case TypeInternalPassport:
+		return d.InternalPassport
+	case TypeDriverLicense:
+		return d.DriverLicense
+	case TypeIdentityCard:
+		return d.IdentityCard
+	default:
+		return nil
+	}
+}
+
+func (d *DecryptedPassportData) document(t string) *PassportDocument {
+	switch t {
+	case TypeUtilityBill:
+		return d.UtilityBill
+	case TypeBankStatement:
+		return d.BankStatement
+	case TypeRentalAgreement:
+		return d.RentalAgreement
+	case TypePassportRegistration:
+		return d.PassportRegistration
+	case TypeTemporaryRegistration:
+		return d.TemporaryRegistration
+	default:
+		return nil
+	}
+}
+
+func identityDocumentTypes() []string {
+	return []string{TypePassport, TypeInternalPassport, TypeDriverLicense, TypeIdentityCard}
+}
+
+func documentTypes() []string {
+	return []string{
+		TypeUtilityBill, TypeBankStatement, TypeRentalAgreement, TypePassportRegistration, TypeTemporaryRegistration,
+	}
+}
+
+func hasType(types []string, t string) bool {
+	if len(types) == 0 {
+		return true
+	}
+
+	for i := range types {
+		if types[i] == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+)
+
+func TestDecryptedPassportDataValidate(t *testing.T) {
+	d := &DecryptedPassportData{
+		Credentials: &Credentials{SecureData: &SecureData{
+			PersonalDetails: &SecureValue{Data: &DataCredentials{DataHash: "pd"}},
+			Passport: &SecureValue{
+				Data:      &DataCredentials{DataHash: "passport"},
+				FrontSide: &FileCredentials{FileHash: "front"},
+			},
+			UtilityBill: &SecureValue{Files: []*FileCredentials{{FileHash: "bill1"}, {FileHash: "bill2"}}},
+		}},
+		Elements: []*EncryptedPassportElement{
+			{Type: TypePersonalDetails, Hash: "pd element"},
+			{Type: TypePassport, Hash: "passport element"},
+			{Type: TypeUtilityBill, Hash: "bill element"},
+		},
+		PersonalDetails: &PersonalDetails{BirthDate: time.Now().AddDate(-10, 0, 0).Format("02.01.2006")},
+		Passport:        &IDDocument{Data: &IDDocumentData{ExpiryDate: "01.01.2001"}, FrontSide: []byte("front")},
+		UtilityBill:     &PassportDocument{Files: [][]byte{[]byte("bill1"), []byte("bill2")}},
+	}
+
+	t.Run("rules", func(t *testing.T) {
+		assert.Equal(t, []PassportElementError{
+			&PassportElementErrorDataField{
+				Source: SourceData, Type: TypePassport, FieldName: "expiry_date", DataHash: "passport",
+				Message: "expired",
+			},
+			&PassportElementErrorDataField{
+				Source: SourceData, Type: TypePersonalDetails, FieldName: "birth_date", DataHash: "pd",
+				Message: "too young",
+			},
+			&PassportElementErrorUnspecified{
+				Source: SourceUnspecified, Type: TypePassport, ElementHash: "passport element",
+				Message: "selfie required",
+			},
+			&PassportElementErrorUnspecified{
+				Source: SourceUnspecified, Type: TypeUtilityBill, ElementHash: "bill element",
+				Message: "translation required",
+			},
+		}, d.Validate(
+			NotExpired("expired"),
+			MinimumAge(18, "too young"),
+			SelfieRequired("selfie required"),
+			TranslationRequired("translation required", TypeUtilityBill),
+		))
+	})
+	t.Run("valid", func(t *testing.T) {
+		assert.Empty(t, d.Validate(MinimumAge(5, "too young"), SelfieRequired("selfie required", TypeDriverLicense)))
+	})
+	t.Run("errors", func(t *testing.T) {
+		assert.Equal(t, &PassportElementErrorFrontSide{
+			Source: SourceFrontSide, Type: TypePassport, FileHash: "front", Message: "blurry",
+		}, d.FrontSideError(TypePassport, "blurry"))
+		assert.Equal(t, &PassportElementErrorFile{
+			Source: SourceFile, Type: TypeUtilityBill, FileHash: "bill2", Message: "blurry",
+		}, d.FileError(TypeUtilityBill, 1, "blurry"))
+		assert.Equal(t, &PassportElementErrorFiles{
+			Source: SourceFiles, Type: TypeUtilityBill, FileHashes: []string{"bill1", "bill2"}, Message: "old",
+		}, d.FilesError(TypeUtilityBill, "old"))
+		assert.Nil(t, d.FileError(TypeUtilityBill, 2, "blurry"))
+		assert.Nil(t, d.SelfieError(TypePassport, "blurry"))
+		assert.Nil(t, d.ReverseSideError(TypeDriverLicense, "blurry"))
+		assert.Nil(t, d.TranslationFilesError(TypePassport, "blurry"))
+		assert.Nil(t, d.UnspecifiedError(TypeAddress, "blurry"))
+	})
+	t.Run("send", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			assert.Equal(t, "/bot123:abc/setPassportDataErrors", string(ctx.Path()))
+			assert.Equal(t, TypePassport, json.Get(ctx.PostBody(), "errors", 0, "type").ToString())
+			assert.Equal(t, SourceData, json.Get(ctx.PostBody(), "errors", 0, "source").ToString())
+			assert.Equal(t, "passport", json.Get(ctx.PostBody(), "errors", 0, "data_hash").ToString())
+			ctx.SetBodyString(`{"ok":true,"result":true}`)
+		})
+		defer stop()
+
+		ok, err := b.SetPassportDataErrors(42, d.Validate(NotExpired("expired"))...)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
@@ -1,5 +1,7 @@
 package telegram
 
+import "context"
+
 type (
 	// LabeledPrice represents a portion of the price for goods or services.
 	LabeledPrice struct {
@@ -250,13 +252,13 @@ func NewInvoice(chatID int64, title, description, payload, providerToken, startP
 
 // SendInvoice send invoices. On success, the sent Message is returned.
 func (b Bot) SendInvoice(p SendInvoice) (*Message, error) {
-	src, err := b.Do(MethodSendInvoice, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.SendInvoiceContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// SendInvoiceContext is the same as SendInvoice, but with a context.Context.
+func (b Bot) SendInvoiceContext(ctx context.Context, p SendInvoice) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodSendInvoice, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -279,13 +281,13 @@ func NewAnswerShipping(shippingQueryID string, ok bool) AnswerShippingQuery {
 //
 // If you sent an invoice requesting a shipping address and the parameter is_flexible was specified, the Bot API will send an Update with a shipping_query field to the b. On success, True is returned.
 func (b Bot) AnswerShippingQuery(p AnswerShippingQuery) (bool, error) {
-	src, err := b.Do(MethodAnswerShippingQuery, p)
-	if err != nil {
-		return false, err
-	}
+	return b.AnswerShippingQueryContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// AnswerShippingQueryContext is the same as AnswerShippingQuery, but with a context.Context.
+func (b Bot) AnswerShippingQueryContext(ctx context.Context, p AnswerShippingQuery) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodAnswerShippingQuery, p)
+	if err != nil {
 		return false, err
 	}
 
@@ -310,13 +312,13 @@ func NewAnswerPreCheckout(preCheckoutQueryID string, ok bool) AnswerPreCheckoutQ
 //
 // Note: The Bot API must receive an answer within 10 seconds after the pre-checkout query was sent.
 func (b Bot) AnswerPreCheckoutQuery(p AnswerShippingQuery) (bool, error) {
-	src, err := b.Do(MethodAnswerPreCheckoutQuery, p)
-	if err != nil {
-		return false, err
-	}
+	return b.AnswerPreCheckoutQueryContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// AnswerPreCheckoutQueryContext is the same as AnswerPreCheckoutQuery, but with a context.Context.
+func (b Bot) AnswerPreCheckoutQueryContext(ctx context.Context, p AnswerShippingQuery) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodAnswerPreCheckoutQuery, p)
+	if err != nil {
 		return false, err
 	}
 
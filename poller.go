@@ -0,0 +1,295 @@
+package telegram
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// Poller receives incoming updates using long polling and sends them into Updates channel (or Handler) until it's
+// stopped.
+type Poller struct {
+	// Updates receives incoming updates if Handler is not set. It's closed after the poller stops.
+	//
+	// The offset is committed as soon as the update is sent into the buffered channel, so updates which are
+	// buffered but not read yet are lost on stop (at-most-once processing). Use Handler for at-least-once
+	// processing.
+	Updates chan *Update
+
+	// Handler handles incoming updates one by one instead of sending them into Updates. The offset is committed
+	// only after the handler succeeds, so unhandled updates will be received again after restart (at-least-once
+	// processing). Failed update is retried with the same backoff as failed requests until it's handled or the
+	// poller stops. Errors of handler are passed into ErrorHandler.
+	Handler Handler
+
+	// Store keeps the offset between restarts. It's loaded on Start and saved after each handled (or sent into
+	// Updates) update.
+	Store OffsetStore
+
+	// ErrorHandler is called for each failed GetUpdates request and each error of Handler. Errors are ignored if
+	// it's nil.
+	ErrorHandler func(err error)
+
+	// Delay before repeating the first failed request. Doubled on each next failure up to MaxBackoff and randomized
+	// by jitter. Defaults to 1 second.
+	MinBackoff time.Duration
+
+	// Maximum delay between failed requests. Defaults to 1 minute.
+	MaxBackoff time.Duration
+
+	bot    *Bot
+	params GetUpdates
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+	mu     sync.Mutex
+}
+
+// ErrPollerStarted is returned by Start of already started or stopped Poller.
+var ErrPollerStarted = xerrors.New("poller already started") //nolint: gochecknoglobals
+
+// NewPoller creates a new Poller with provided GetUpdates parameters. By default poller receives up to 100 updates
+// per request with 60 seconds timeout.
+func (b *Bot) NewPoller(params *GetUpdates) *Poller {
+	if params == nil {
+		params = &GetUpdates{
+			Offset:  0,
+			Limit:   100,
+			Timeout: 60,
+		}
+	}
+
+	return &Poller{
+		Updates:    make(chan *Update, params.Limit),
+		MinBackoff: time.Second,
+		MaxBackoff: time.Minute,
+		bot:        b,
+		params:     *params,
+		done:       make(chan struct{}),
+	}
+}
+
+// Start starts receiving of updates in background until ctx is done, Stop is called or a fatal error happens.
+func (p *Poller) Start(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		return ErrPollerStarted
+	}
+
+	if p.Store != nil {
+		offset, err := p.Store.Load(ctx)
+		if err != nil {
+			return err
+		}
+
+		if offset > p.params.Offset {
+			p.params.Offset = offset
+		}
+	}
+
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	go p.poll(ctx)
+
+	return nil
+}
+
+// Stop stops receiving of updates, waits until the offset of delivered updates is acknowledged and returns the fatal
+// error which stopped the poller, if any.
+func (p *Poller) Stop() error {
+	p.mu.Lock()
+	cancel := p.cancel
+	p.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	<-p.done
+
+	return p.Err()
+}
+
+// Done returns a channel which is closed when the poller stops.
+func (p *Poller) Done() <-chan struct{} { return p.done }
+
+// Err returns the fatal error which stopped the poller, if any.
+func (p *Poller) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.err
+}
+
+// Offset returns the identifier of the next expected update.
+func (p *Poller) Offset() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.params.Offset
+}
+
+func (p *Poller) poll(ctx context.Context) {
+	defer close(p.done)
+	defer close(p.Updates)
+	defer p.acknowledge()
+
+	var failures int
+
+	for ctx.Err() == nil {
+		p.mu.Lock()
+		params := p.params
+		p.mu.Unlock()
+
+		updates, err := p.bot.GetUpdatesContext(ctx, &params)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if p.ErrorHandler != nil {
+				p.ErrorHandler(err)
+			}
+
+			if isFatal(err) {
+				p.mu.Lock()
+				p.err = err
+				p.mu.Unlock()
+
+				return
+			}
+
+			failures++
+
+			if !sleep(ctx, p.backoff(failures)) {
+				return
+			}
+
+			continue
+		}
+
+		failures = 0
+
+		for _, update := range updates {
+			if update.UpdateID < params.Offset {
+				continue
+			}
+
+			if !p.deliver(ctx, update) {
+				return
+			}
+
+			p.commit(ctx, update.UpdateID+1)
+		}
+	}
+}
+
+// deliver sends update into Handler or Updates channel. Failed update is handled again after backoff. It returns
+// false if ctx is done before the update is delivered, so the update is not committed.
+func (p *Poller) deliver(ctx context.Context, u *Update) bool {
+	if p.Handler == nil {
+		select {
+		case <-ctx.Done():
+			return false
+		case p.Updates <- u:
+			return true
+		}
+	}
+
+	for failures := 1; ; failures++ {
+		err := p.Handler.HandleUpdate(ctx, u)
+		if err == nil {
+			return true
+		}
+
+		if ctx.Err() != nil {
+			return false
+		}
+
+		if p.ErrorHandler != nil {
+			p.ErrorHandler(err)
+		}
+
+		if !sleep(ctx, p.backoff(failures)) {
+			return false
+		}
+	}
+}
+
+// commit stores the offset of the next expected update.
+func (p *Poller) commit(ctx context.Context, offset int) {
+	p.mu.Lock()
+	p.params.Offset = offset
+	p.mu.Unlock()
+
+	if p.Store == nil {
+		return
+	}
+
+	if err := p.Store.Save(ctx, offset); err != nil && p.ErrorHandler != nil {
+		p.ErrorHandler(err)
+	}
+}
+
+// acknowledge confirms all delivered updates on Telegram side, so they will not be received again.
+func (p *Poller) acknowledge() {
+	offset := p.Offset()
+	if offset == 0 || p.Err() != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := p.bot.GetUpdatesContext(ctx, &GetUpdates{Offset: offset, Limit: 1}); err != nil &&
+		p.ErrorHandler != nil {
+		p.ErrorHandler(err)
+	}
+}
+
+// backoff returns randomized delay before the next request after the number of failures in a row.
+func (p *Poller) backoff(failures int) time.Duration {
+	result := p.MinBackoff
+	if result <= 0 {
+		result = time.Second
+	}
+
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = time.Minute
+	}
+
+	for i := 1; i < failures && result < max; i++ {
+		result *= 2
+	}
+
+	if result > max {
+		result = max
+	}
+
+	return result/2 + time.Duration(rand.Int63n(int64(result/2)+1)) //nolint: gosec
+}
+
+// isFatal checks that GetUpdates will never succeed after err: token is invalid or webhook is set up.
+func isFatal(err error) bool {
+	return xerrors.Is(err, ErrUnauthorized) || xerrors.Is(err, ErrNotFound) || xerrors.Is(err, ErrConflict)
+}
+
+// sleep waits for d or until ctx is done. It returns false if ctx is done.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,182 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+func TestPoller(t *testing.T) {
+	t.Run("stop", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			offsets []int
+		)
+
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			p := new(GetUpdates)
+			_ = json.ConfigFastest.Unmarshal(ctx.Request.Body(), p)
+			offsets = append(offsets, p.Offset)
+
+			if p.Offset == 0 {
+				ctx.SetBodyString(`{"ok":true,"result":[{"update_id":10},{"update_id":11}]}`)
+				return
+			}
+
+			ctx.SetBodyString(`{"ok":true,"result":[]}`)
+		})
+		defer stop()
+
+		p := b.NewPoller(&GetUpdates{Limit: 100})
+		assert.NoError(t, p.Start(context.Background()))
+		assert.Equal(t, ErrPollerStarted, p.Start(context.Background()))
+		assert.Equal(t, 10, (<-p.Updates).UpdateID)
+		assert.Equal(t, 11, (<-p.Updates).UpdateID)
+		assert.NoError(t, p.Stop())
+
+		_, ok := <-p.Updates
+		assert.False(t, ok)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.Equal(t, 0, offsets[0])
+		assert.Equal(t, 12, offsets[len(offsets)-1])
+	})
+	t.Run("fatal", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			ctx.SetStatusCode(http.StatusConflict)
+			ctx.SetBodyString(`{"ok":false,"error_code":409,"description":"Conflict: can't use getUpdates method ` +
+				`while webhook is active"}`)
+		})
+		defer stop()
+
+		var reported error
+
+		p := b.NewPoller(nil)
+		p.ErrorHandler = func(err error) { reported = err }
+		assert.NoError(t, p.Start(context.Background()))
+
+		select {
+		case <-p.Done():
+		case <-time.After(time.Second):
+			t.Fatal("poller is not stopped")
+		}
+
+		assert.True(t, xerrors.Is(p.Err(), ErrConflict))
+		assert.Equal(t, p.Err(), reported)
+		assert.True(t, xerrors.Is(p.Stop(), ErrConflict))
+	})
+}
+
+func TestPollerStore(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		offsets []int
+	)
+
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		p := new(GetUpdates)
+		_ = json.ConfigFastest.Unmarshal(ctx.Request.Body(), p)
+		offsets = append(offsets, p.Offset)
+
+		if p.Offset == 5 {
+			ctx.SetBodyString(`{"ok":true,"result":[{"update_id":5},{"update_id":6}]}`)
+			return
+		}
+
+		ctx.SetBodyString(`{"ok":true,"result":[]}`)
+	})
+	defer stop()
+
+	store := NewMemoryOffsetStore()
+	assert.NoError(t, store.Save(context.Background(), 5))
+
+	handled := make(chan int)
+	p := b.NewPoller(nil)
+	p.Store = store
+	p.Handler = HandlerFunc(func(ctx context.Context, u *Update) error {
+		handled <- u.UpdateID
+
+		return nil
+	})
+	assert.NoError(t, p.Start(context.Background()))
+
+	assert.Equal(t, 5, <-handled)
+	assert.Equal(t, 6, <-handled)
+	assert.NoError(t, p.Stop())
+
+	offset, err := store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 7, offset)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, 5, offsets[0])
+	assert.Equal(t, 7, offsets[len(offsets)-1])
+}
+
+func TestPollerHandlerRetry(t *testing.T) {
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		p := new(GetUpdates)
+		_ = json.ConfigFastest.Unmarshal(ctx.Request.Body(), p)
+
+		if p.Offset <= 5 {
+			ctx.SetBodyString(`{"ok":true,"result":[{"update_id":5}]}`)
+			return
+		}
+
+		ctx.SetBodyString(`{"ok":true,"result":[]}`)
+	})
+	defer stop()
+
+	var (
+		attempts int
+		errs     = make(chan error, 1)
+		handled  = make(chan int)
+	)
+
+	p := b.NewPoller(nil)
+	p.MinBackoff = time.Millisecond
+	p.ErrorHandler = func(err error) { errs <- err }
+	p.Handler = HandlerFunc(func(ctx context.Context, u *Update) error {
+		if attempts++; attempts == 1 {
+			assert.Equal(t, 0, p.Offset())
+
+			return assert.AnError
+		}
+
+		handled <- u.UpdateID
+
+		return nil
+	})
+	assert.NoError(t, p.Start(context.Background()))
+
+	assert.Equal(t, assert.AnError, <-errs)
+	assert.Equal(t, 5, <-handled)
+	assert.NoError(t, p.Stop())
+	assert.Equal(t, 6, p.Offset())
+	assert.Equal(t, 2, attempts)
+}
+
+func TestPollerBackoff(t *testing.T) {
+	p := &Poller{MinBackoff: time.Second, MaxBackoff: 4 * time.Second}
+
+	for failures, max := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 5: 4 * time.Second} {
+		d := p.backoff(failures)
+		assert.True(t, d >= max/2 && d <= max, "%d: %s", failures, d)
+	}
+}
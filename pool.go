@@ -0,0 +1,135 @@
+package telegram
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// Pool handles updates concurrently by a fixed number of workers. Updates are sharded between workers by chat
+// identifier (or by user identifier for inline queries, chosen inline results and callback queries), so updates
+// from one chat are handled strictly in order while different chats are handled in parallel.
+type Pool struct {
+	// ErrorHandler is called for each update which handler returns an error. Errors are ignored if it's nil.
+	ErrorHandler func(ctx context.Context, u *Update, err error)
+
+	handler   Handler
+	workers   int
+	queueSize int
+}
+
+// detachedContext keeps values of the parent context, but it's never canceled.
+type detachedContext struct{ context.Context }
+
+// NewPool creates a new Pool with provided number of workers and bounded queue size of each worker.
+func NewPool(h Handler, workers, queueSize int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	return &Pool{
+		handler:   h,
+		workers:   workers,
+		queueSize: queueSize,
+	}
+}
+
+// Run reads updates from channel and distributes them between workers until the channel is closed or ctx is done.
+// Reading blocks while the queue of the target worker is full. Before return Run waits until all already read updates
+// are handled, including the one which waits for the queue when ctx is done. Updates which are handled after ctx is
+// done receive the context with values of ctx which is never canceled.
+func (p *Pool) Run(ctx context.Context, updates <-chan *Update) error {
+	queues := make([]chan *Update, p.workers)
+
+	var wg sync.WaitGroup
+
+	for i := range queues {
+		queues[i] = make(chan *Update, p.queueSize)
+
+		wg.Add(1)
+
+		go func(queue <-chan *Update) {
+			defer wg.Done()
+
+			for u := range queue {
+				hctx := ctx
+				if ctx.Err() != nil {
+					hctx = detachedContext{ctx}
+				}
+
+				if err := p.handler.HandleUpdate(hctx, u); err != nil && p.ErrorHandler != nil {
+					p.ErrorHandler(hctx, u, err)
+				}
+			}
+		}(queues[i])
+	}
+
+	defer func() {
+		for i := range queues {
+			close(queues[i])
+		}
+
+		wg.Wait()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case u, ok := <-updates:
+			if !ok {
+				return nil
+			}
+
+			if u == nil {
+				continue
+			}
+
+			queue := queues[shardKey(u)%uint64(len(queues))]
+
+			select {
+			case <-ctx.Done():
+				queue <- u
+
+				return ctx.Err()
+			case queue <- u:
+			}
+		}
+	}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
+
+// shardKey returns the key of the update for sharding between workers.
+func shardKey(u *Update) uint64 {
+	switch {
+	case u.IsInlineQuery(), u.IsChosenInlineResult(), u.IsCallbackQuery():
+		if user := u.EffectiveUser(); user != nil {
+			return uint64(user.ID)
+		}
+	case u.IsPoll():
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(u.Poll.ID))
+
+		return h.Sum64()
+	}
+
+	if chat := u.EffectiveChat(); chat != nil {
+		return uint64(chat.ID)
+	}
+
+	if user := u.EffectiveUser(); user != nil {
+		return uint64(user.ID)
+	}
+
+	return uint64(u.UpdateID)
+}
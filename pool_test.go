@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolRun(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		result = make(map[int64][]int)
+	)
+
+	p := NewPool(HandlerFunc(func(ctx context.Context, u *Update) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		result[u.Message.Chat.ID] = append(result[u.Message.Chat.ID], u.Message.ID)
+
+		return nil
+	}), 4, 2)
+
+	updates := make(chan *Update)
+	done := make(chan error)
+
+	go func() { done <- p.Run(context.Background(), updates) }()
+
+	for i := 0; i < 100; i++ {
+		updates <- &Update{UpdateID: i, Message: &Message{ID: i, Chat: &Chat{ID: int64(i % 7)}}}
+	}
+
+	close(updates)
+	assert.NoError(t, <-done)
+
+	var total int
+
+	for chatID, ids := range result {
+		total += len(ids)
+
+		for i := 1; i < len(ids); i++ {
+			assert.True(t, ids[i-1] < ids[i], "chat %d: %v", chatID, ids)
+		}
+	}
+
+	assert.Len(t, result, 7)
+	assert.Equal(t, 100, total)
+}
+
+func TestShardKey(t *testing.T) {
+	from := &User{ID: 42}
+	chat := &Chat{ID: -100}
+
+	assert.Equal(t, uint64(42), shardKey(&Update{CallbackQuery: &CallbackQuery{
+		From: from, Message: &Message{Chat: chat},
+	}}))
+	assert.Equal(t, uint64(42), shardKey(&Update{InlineQuery: &InlineQuery{From: from}}))
+	assert.Equal(t, shardKey(&Update{Message: &Message{Chat: chat}}),
+		shardKey(&Update{EditedMessage: &Message{Chat: chat}}))
+}
+
+func TestPoolRunDrain(t *testing.T) {
+	type key struct{}
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+		errs    = make(chan error, 3)
+	)
+
+	p := NewPool(HandlerFunc(func(ctx context.Context, u *Update) error {
+		if u.UpdateID == 1 {
+			close(started)
+			<-release
+		}
+
+		assert.Equal(t, "value", ctx.Value(key{}))
+		errs <- ctx.Err()
+
+		return nil
+	}), 1, 1)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	updates := make(chan *Update)
+	done := make(chan error)
+
+	go func() { done <- p.Run(ctx, updates) }()
+
+	updates <- &Update{UpdateID: 1}
+	<-started
+	updates <- &Update{UpdateID: 2}
+	updates <- &Update{UpdateID: 3} // waits for the full queue
+
+	cancel()
+	close(release)
+
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Equal(t, context.Canceled, <-errs)
+	assert.NoError(t, <-errs)
+	assert.NoError(t, <-errs)
+}
@@ -0,0 +1,91 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+// RetryPolicy describes how the Bot repeats failed requests.
+//
+// Requests rejected by flood control are repeated after ResponseParameters.RetryAfter seconds. Requests failed by
+// server (5xx), flood control without RetryAfter or network errors are repeated with exponential backoff. Other
+// errors are returned as is.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one. Values less than 2 disables retries.
+	MaxAttempts int
+
+	// Maximum total time to wait between all attempts of one request. Zero means no limit.
+	MaxWait time.Duration
+
+	// Delay before the second attempt on 5xx and network errors. Doubled on each next attempt. Defaults to 1 second.
+	MinBackoff time.Duration
+
+	// Maximum delay between attempts on 5xx and network errors. Zero means no limit.
+	MaxBackoff time.Duration
+}
+
+const defaultMinBackoff = time.Second
+
+// NewRetryPolicy creates a new RetryPolicy with provided maximum number of attempts and total wait budget.
+func NewRetryPolicy(maxAttempts int, maxWait time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		MaxWait:     maxWait,
+		MinBackoff:  defaultMinBackoff,
+		MaxBackoff:  maxWait,
+	}
+}
+
+// SetRetryPolicy enables repeating of failed requests by provided policy. Nil policy disables retries.
+func (b *Bot) SetRetryPolicy(p *RetryPolicy) {
+	if b == nil {
+		b = new(Bot)
+	}
+
+	b.retry = p
+}
+
+// delay returns the time to wait before the next attempt after the failed attempt with err, or false if request
+// must not be repeated.
+func (p *RetryPolicy) delay(attempt int, waited time.Duration, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts ||
+		xerrors.Is(err, context.Canceled) || xerrors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+
+	var (
+		result time.Duration
+		tgErr  *Error
+	)
+
+	isAPIErr := xerrors.As(err, &tgErr)
+
+	switch {
+	case isAPIErr && tgErr.RetryAfter() > 0:
+		result = time.Duration(tgErr.RetryAfter()) * time.Second
+	case isAPIErr && tgErr.Code < http.StatusInternalServerError && tgErr.Code != http.StatusTooManyRequests:
+		return 0, false
+	default:
+		result = p.MinBackoff
+		if result <= 0 {
+			result = defaultMinBackoff
+		}
+
+		for i := 1; i < attempt && (p.MaxBackoff <= 0 || result < p.MaxBackoff); i++ {
+			result *= 2
+		}
+
+		if p.MaxBackoff > 0 && result > p.MaxBackoff {
+			result = p.MaxBackoff
+		}
+	}
+
+	if p.MaxWait > 0 && waited+result > p.MaxWait {
+		return 0, false
+	}
+
+	return result, true
+}
@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 4, MaxWait: 10 * time.Second, MinBackoff: time.Second, MaxBackoff: 3 * time.Second}
+	floodErr := &Error{Code: http.StatusTooManyRequests, Parameters: &ResponseParameters{RetryAfter: 5}}
+	serverErr := &Error{Code: http.StatusBadGateway}
+
+	for _, tc := range []struct {
+		name     string
+		attempt  int
+		waited   time.Duration
+		err      error
+		expDelay time.Duration
+		expOk    bool
+	}{
+		{name: "flood", attempt: 1, err: floodErr, expDelay: 5 * time.Second, expOk: true},
+		{name: "flood over budget", attempt: 2, waited: 6 * time.Second, err: floodErr},
+		{name: "server first", attempt: 1, err: serverErr, expDelay: time.Second, expOk: true},
+		{name: "server second", attempt: 2, err: serverErr, expDelay: 2 * time.Second, expOk: true},
+		{name: "server capped", attempt: 3, err: serverErr, expDelay: 3 * time.Second, expOk: true},
+		{name: "network", attempt: 1, err: xerrors.New("connection reset"), expDelay: time.Second, expOk: true},
+		{name: "bad request", attempt: 1, err: &Error{Code: http.StatusBadRequest}},
+		{name: "attempts exceeded", attempt: 4, err: serverErr},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			delay, ok := p.delay(tc.attempt, tc.waited, tc.err)
+			assert.Equal(t, tc.expOk, ok)
+			assert.Equal(t, tc.expDelay, delay)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		var p *RetryPolicy
+		_, ok := p.delay(1, 0, serverErr)
+		assert.False(t, ok)
+	})
+}
+
+func TestBotRetry(t *testing.T) {
+	var calls int
+
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		calls++
+		if calls == 1 {
+			ctx.SetStatusCode(http.StatusTooManyRequests)
+			ctx.SetBodyString(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 0"}`)
+
+			return
+		}
+
+		ctx.SetBodyString(`{"ok":true,"result":true}`)
+	})
+	defer stop()
+
+	b.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+
+	ok, err := b.SendChatAction(42, ActionTyping)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,265 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+)
+
+type (
+	// Router routes incoming updates to the first registered handler which matches the update type and all its
+	// filters. Handlers must be registered before the first update is routed.
+	Router struct {
+		// ErrorHandler is called by Run for each update which handler returns an error. Errors are ignored if it's
+		// nil.
+		ErrorHandler func(ctx context.Context, u *Update, err error)
+
+		routes      []route
+		middlewares []Middleware
+	}
+
+	// Filter checks that the update must be handled by handler.
+	Filter func(u *Update) bool
+
+	// MessageHandler handles new or edited messages and channel posts.
+	MessageHandler func(ctx context.Context, m *Message) error
+
+	// InlineQueryHandler handles incoming inline queries.
+	InlineQueryHandler func(ctx context.Context, q *InlineQuery) error
+
+	// ChosenInlineResultHandler handles results of inline queries chosen by users.
+	ChosenInlineResultHandler func(ctx context.Context, r *ChosenInlineResult) error
+
+	// CallbackQueryHandler handles incoming callback queries.
+	CallbackQueryHandler func(ctx context.Context, q *CallbackQuery) error
+
+	// ShippingQueryHandler handles incoming shipping queries.
+	ShippingQueryHandler func(ctx context.Context, q *ShippingQuery) error
+
+	// PreCheckoutQueryHandler handles incoming pre-checkout queries.
+	PreCheckoutQueryHandler func(ctx context.Context, q *PreCheckoutQuery) error
+
+	// PollHandler handles new poll states.
+	PollHandler func(ctx context.Context, p *Poll) error
+
+	// PollAnswerHandler handles changed answers of users in non-anonymous polls.
+	PollAnswerHandler func(ctx context.Context, a *PollAnswer) error
+
+	route struct {
+		kind    string
+		filters []Filter
+		handle  func(ctx context.Context, u *Update) error
+	}
+)
+
+// NewRouter creates a new empty Router.
+func NewRouter() *Router {
+	return &Router{routes: make([]route, 0)}
+}
+
+// OnMessage registers handler of new incoming messages.
+func (r *Router) OnMessage(h MessageHandler, filters ...Filter) {
+	r.add(UpdateMessage, filters, func(ctx context.Context, u *Update) error { return h(ctx, u.Message) })
+}
+
+// OnEditedMessage registers handler of edited messages.
+func (r *Router) OnEditedMessage(h MessageHandler, filters ...Filter) {
+	r.add(UpdateEditedMessage, filters, func(ctx context.Context, u *Update) error { return h(ctx, u.EditedMessage) })
+}
+
+// OnChannelPost registers handler of new incoming channel posts.
+func (r *Router) OnChannelPost(h MessageHandler, filters ...Filter) {
+	r.add(UpdateChannelPost, filters, func(ctx context.Context, u *Update) error { return h(ctx, u.ChannelPost) })
+}
+
+// OnEditedChannelPost registers handler of edited channel posts.
+func (r *Router) OnEditedChannelPost(h MessageHandler, filters ...Filter) {
+	r.add(UpdateEditedChannelPost, filters, func(ctx context.Context, u *Update) error {
+		return h(ctx, u.EditedChannelPost)
+	})
+}
+
+// OnInlineQuery registers handler of inline queries.
+func (r *Router) OnInlineQuery(h InlineQueryHandler, filters ...Filter) {
+	r.add(UpdateInlineQuery, filters, func(ctx context.Context, u *Update) error { return h(ctx, u.InlineQuery) })
+}
+
+// OnChosenInlineResult registers handler of chosen inline results.
+func (r *Router) OnChosenInlineResult(h ChosenInlineResultHandler, filters ...Filter) {
+	r.add(UpdateChosenInlineResult, filters, func(ctx context.Context, u *Update) error {
+		return h(ctx, u.ChosenInlineResult)
+	})
+}
+
+// OnCallbackQuery registers handler of callback queries.
+func (r *Router) OnCallbackQuery(h CallbackQueryHandler, filters ...Filter) {
+	r.add(UpdateCallbackQuery, filters, func(ctx context.Context, u *Update) error {
+		return h(ctx, u.CallbackQuery)
+	})
+}
+
+// OnShippingQuery registers handler of shipping queries.
+func (r *Router) OnShippingQuery(h ShippingQueryHandler, filters ...Filter) {
+	r.add(UpdateShippingQuery, filters, func(ctx context.Context, u *Update) error {
+		return h(ctx, u.ShippingQuery)
+	})
+}
+
+// OnPreCheckoutQuery registers handler of pre-checkout queries.
+func (r *Router) OnPreCheckoutQuery(h PreCheckoutQueryHandler, filters ...Filter) {
+	r.add(UpdatePreCheckoutQuery, filters, func(ctx context.Context, u *Update) error {
+		return h(ctx, u.PreCheckoutQuery)
+	})
+}
+
+// OnPoll registers handler of poll states.
+func (r *Router) OnPoll(h PollHandler, filters ...Filter) {
+	r.add(UpdatePoll, filters, func(ctx context.Context, u *Update) error { return h(ctx, u.Poll) })
+}
+
+// OnPollAnswer registers handler of poll answers.
+func (r *Router) OnPollAnswer(h PollAnswerHandler, filters ...Filter) {
+	r.add(UpdatePollAnswer, filters, func(ctx context.Context, u *Update) error { return h(ctx, u.PollAnswer) })
+}
+
+// Use adds middlewares which are called around each routed update in the order of adding.
+func (r *Router) Use(middlewares ...Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// HandleUpdate routes the update through middlewares to the first matching handler and returns its error. Updates
+// without matching handlers are ignored.
+func (r *Router) HandleUpdate(ctx context.Context, u *Update) error {
+	if u == nil {
+		return nil
+	}
+
+	return Chain(HandlerFunc(r.route), r.middlewares...).HandleUpdate(ctx, u)
+}
+
+func (r *Router) route(ctx context.Context, u *Update) error {
+	kind := u.Type()
+
+	for i := range r.routes {
+		if r.routes[i].kind != kind || !r.routes[i].match(u) {
+			continue
+		}
+
+		return r.routes[i].handle(ctx, u)
+	}
+
+	return nil
+}
+
+// Run routes updates from channel one by one until it's closed or ctx is done.
+func (r *Router) Run(ctx context.Context, updates <-chan *Update) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case u, ok := <-updates:
+			if !ok {
+				return nil
+			}
+
+			if err := r.HandleUpdate(ctx, u); err != nil && r.ErrorHandler != nil {
+				r.ErrorHandler(ctx, u, err)
+			}
+		}
+	}
+}
+
+func (r *Router) add(kind string, filters []Filter, handle func(ctx context.Context, u *Update) error) {
+	r.routes = append(r.routes, route{kind: kind, filters: filters, handle: handle})
+}
+
+func (rt route) match(u *Update) bool {
+	for i := range rt.filters {
+		if !rt.filters[i](u) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// And creates a filter which matches the update if all filters matches it.
+func And(filters ...Filter) Filter {
+	return func(u *Update) bool {
+		for i := range filters {
+			if !filters[i](u) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Or creates a filter which matches the update if any of filters matches it.
+func Or(filters ...Filter) Filter {
+	return func(u *Update) bool {
+		for i := range filters {
+			if filters[i](u) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not creates a filter which matches the update if filter does not match it.
+func Not(filter Filter) Filter {
+	return func(u *Update) bool { return !filter(u) }
+}
+
+// Command creates a filter which matches messages with one of provided bot commands.
+func Command(commands ...string) Filter {
+	return func(u *Update) bool {
+		m := u.EffectiveMessage()
+		if m == nil {
+			return false
+		}
+
+		for i := range commands {
+			if m.IsCommandEqual(commands[i]) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Private creates a filter which matches messages in private chats.
+func Private() Filter {
+	return func(u *Update) bool {
+		m := u.EffectiveMessage()
+
+		return m != nil && m.Chat != nil && m.Chat.IsPrivate()
+	}
+}
+
+// Group creates a filter which matches messages in groups and supergroups.
+func Group() Filter {
+	return func(u *Update) bool {
+		m := u.EffectiveMessage()
+
+		return m != nil && m.Chat != nil && (m.Chat.IsGroup() || m.Chat.IsSuperGroup())
+	}
+}
+
+// ToMe creates a filter which matches messages addressed to the bot. It matches nothing if b has no User, which is
+// set by New.
+func ToMe(b *Bot) Filter {
+	return func(u *Update) bool {
+		m := u.EffectiveMessage()
+
+		return m != nil && b != nil && b.User != nil && b.IsMessageToMe(*m)
+	}
+}
+
+// CallbackData creates a filter which matches callback queries with data started by prefix.
+func CallbackData(prefix string) Filter {
+	return func(u *Update) bool { return u.IsCallbackQuery() && strings.HasPrefix(u.CallbackQuery.Data, prefix) }
+}
@@ -0,0 +1,91 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterHandleUpdate(t *testing.T) {
+	var handled string
+
+	r := NewRouter()
+	r.OnMessage(func(ctx context.Context, m *Message) error {
+		handled = "start"
+		return nil
+	}, Command(CommandStart), Private())
+	r.OnMessage(func(ctx context.Context, m *Message) error {
+		handled = "message"
+		return nil
+	})
+	r.OnCallbackQuery(func(ctx context.Context, q *CallbackQuery) error {
+		handled = "callback"
+		return nil
+	}, CallbackData("vote:"))
+
+	command := &Message{
+		Text:     "/start",
+		Chat:     &Chat{Type: ChatPrivate},
+		Entities: []*MessageEntity{{Type: EntityBotCommand, Length: 6}},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		update    *Update
+		expResult string
+	}{{
+		name:      "command",
+		update:    &Update{Message: command},
+		expResult: "start",
+	}, {
+		name: "command in group",
+		update: &Update{Message: &Message{
+			Text:     command.Text,
+			Chat:     &Chat{Type: ChatGroup},
+			Entities: command.Entities,
+		}},
+		expResult: "message",
+	}, {
+		name:      "callback",
+		update:    &Update{CallbackQuery: &CallbackQuery{Data: "vote:up"}},
+		expResult: "callback",
+	}, {
+		name:   "unmatched callback",
+		update: &Update{CallbackQuery: &CallbackQuery{Data: "other"}},
+	}, {
+		name:   "unhandled",
+		update: &Update{InlineQuery: &InlineQuery{ID: "abc"}},
+	}} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			handled = ""
+			assert.NoError(t, r.HandleUpdate(context.Background(), tc.update))
+			assert.Equal(t, tc.expResult, handled)
+		})
+	}
+}
+
+func TestFilters(t *testing.T) {
+	yes := func(*Update) bool { return true }
+	no := func(*Update) bool { return false }
+	u := new(Update)
+
+	assert.True(t, And(yes, yes)(u))
+	assert.False(t, And(yes, no)(u))
+	assert.True(t, Or(no, yes)(u))
+	assert.False(t, Or(no, no)(u))
+	assert.True(t, Not(no)(u))
+
+	t.Run("to me", func(t *testing.T) {
+		u := &Update{Message: &Message{
+			Chat:     &Chat{Type: ChatGroup},
+			Text:     "/start@bot",
+			Entities: []*MessageEntity{{Type: EntityBotCommand, Offset: 0, Length: 10}},
+		}}
+
+		assert.False(t, ToMe(nil)(u))
+		assert.False(t, ToMe(new(Bot))(u))
+		assert.True(t, ToMe(&Bot{User: &User{Username: "bot"}})(u))
+	})
+}
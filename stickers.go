@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"context"
 	"strconv"
 	"strings"
 )
@@ -198,13 +199,24 @@ func NewSticker(chatID int64, sticker *InputFile) SendSticker {
 
 // SendSticker send .webp stickers. On success, the sent Message is returned.
 func (b Bot) SendSticker(p SendSticker) (*Message, error) {
-	src, err := b.Do(MethodSendSticker, p)
-	if err != nil {
+	return b.SendStickerContext(context.Background(), p)
+}
+
+// SendStickerContext is the same as SendSticker, but with a context.Context.
+func (b Bot) SendStickerContext(ctx context.Context, p SendSticker) (*Message, error) {
+	params := make(map[string]string)
+	params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
+	params["disable_notification"] = strconv.FormatBool(p.DisableNotification)
+	params["reply_to_message_id"] = strconv.Itoa(p.ReplyToMessageID)
+
+	var err error
+	if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+	parts := attachFiles(params, map[string]*InputFile{"sticker": p.Sticker})
+	resp, err := b.UploadContext(ctx, MethodSendSticker, params, parts...)
+	if err != nil {
 		return nil, err
 	}
 
@@ -218,13 +230,13 @@ func (b Bot) SendSticker(p SendSticker) (*Message, error) {
 
 // GetStickerSet get a sticker set. On success, a StickerSet object is returned.
 func (b Bot) GetStickerSet(name string) (*StickerSet, error) {
-	src, err := b.Do(MethodGetStickerSet, GetStickerSet{Name: name})
-	if err != nil {
-		return nil, err
-	}
+	return b.GetStickerSetContext(context.Background(), name)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetStickerSetContext is the same as GetStickerSet, but with a context.Context.
+func (b Bot) GetStickerSetContext(ctx context.Context, name string) (*StickerSet, error) {
+	resp, err := b.DoContext(ctx, MethodGetStickerSet, GetStickerSet{Name: name})
+	if err != nil {
 		return nil, err
 	}
 
@@ -238,24 +250,20 @@ func (b Bot) GetStickerSet(name string) (*StickerSet, error) {
 
 // UploadStickerFile upload a .png file with a sticker for later use in createNewStickerSet and addStickerToSet methods (can be used multiple times). Returns the uploaded File on success.
 func (b Bot) UploadStickerFile(uid int, sticker *InputFile) (*File, error) {
+	return b.UploadStickerFileContext(context.Background(), uid, sticker)
+}
+
+// UploadStickerFileContext is the same as UploadStickerFile, but with a context.Context.
+func (b Bot) UploadStickerFileContext(ctx context.Context, uid int, sticker *InputFile) (*File, error) {
 	params := make(map[string]string)
 	params["user_id"] = strconv.Itoa(uid)
 
-	var err error
-	if params["png_sticker"], err = b.marshler.MarshalToString(sticker); err != nil {
-		return nil, err
-	}
-
-	src, err := b.Upload(MethodUploadStickerFile, params, sticker)
+	parts := attachFiles(params, map[string]*InputFile{"png_sticker": sticker})
+	resp, err := b.UploadContext(ctx, MethodUploadStickerFile, params, parts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return nil, err
-	}
-
 	result := new(File)
 	if err = b.marshler.Unmarshal(resp.Result, result); err != nil {
 		return nil, err
@@ -276,6 +284,11 @@ func NewStickerSet(userID int, name, title string, pngSticker *InputFile, emojis
 
 // CreateNewStickerSet create new sticker set owned by a user. The bot will be able to edit the created sticker set. Returns True on success.
 func (b *Bot) CreateNewStickerSet(p CreateNewStickerSet) (bool, error) {
+	return b.CreateNewStickerSetContext(context.Background(), p)
+}
+
+// CreateNewStickerSetContext is the same as CreateNewStickerSet, but with a context.Context.
+func (b *Bot) CreateNewStickerSetContext(ctx context.Context, p CreateNewStickerSet) (bool, error) {
 	params := make(map[string]string)
 	params["user_id"] = strconv.Itoa(p.UserID)
 	params["name"] = p.Name
@@ -284,29 +297,16 @@ func (b *Bot) CreateNewStickerSet(p CreateNewStickerSet) (bool, error) {
 	params["contains_masks"] = strconv.FormatBool(p.ContainsMasks)
 
 	var err error
-	if params["png_sticker"], err = b.marshler.MarshalToString(p.PNGSticker); err != nil {
-		return false, err
-	}
-
 	if params["mask_position"], err = b.marshler.MarshalToString(p.MaskPosition); err != nil {
 		return false, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.PNGSticker.IsAttachment() {
-		files = append(files, p.PNGSticker)
-	}
-
-	src, err := b.Upload(MethodCreateNewStickerSet, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"png_sticker": p.PNGSticker})
+	resp, err := b.UploadContext(ctx, MethodCreateNewStickerSet, params, parts...)
 	if err != nil {
 		return false, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return false, err
-	}
-
 	var result bool
 	if err = b.marshler.Unmarshal(resp.Result, &result); err != nil {
 		return false, err
@@ -317,35 +317,27 @@ func (b *Bot) CreateNewStickerSet(p CreateNewStickerSet) (bool, error) {
 
 // AddStickerToSet add a new sticker to a set created by the b. Returns True on success.
 func (b *Bot) AddStickerToSet(p AddStickerToSet) (bool, error) {
+	return b.AddStickerToSetContext(context.Background(), p)
+}
+
+// AddStickerToSetContext is the same as AddStickerToSet, but with a context.Context.
+func (b *Bot) AddStickerToSetContext(ctx context.Context, p AddStickerToSet) (bool, error) {
 	params := make(map[string]string)
 	params["user_id"] = strconv.Itoa(p.UserID)
 	params["name"] = p.Name
 	params["emojis"] = p.Emojis
 
 	var err error
-	if params["png_sticker"], err = b.marshler.MarshalToString(p.PNGSticker); err != nil {
-		return false, err
-	}
-
 	if params["mask_position"], err = b.marshler.MarshalToString(p.MaskPosition); err != nil {
 		return false, err
 	}
 
-	files := make([]*InputFile, 0)
-	if p.PNGSticker.IsAttachment() {
-		files = append(files, p.PNGSticker)
-	}
-
-	src, err := b.Upload(MethodAddStickerToSet, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"png_sticker": p.PNGSticker})
+	resp, err := b.UploadContext(ctx, MethodAddStickerToSet, params, parts...)
 	if err != nil {
 		return false, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return false, err
-	}
-
 	var result bool
 	if err = b.marshler.Unmarshal(resp.Result, &result); err != nil {
 		return false, err
@@ -356,7 +348,12 @@ func (b *Bot) AddStickerToSet(p AddStickerToSet) (bool, error) {
 
 // SetStickerPositionInSet move a sticker in a set created by the bot to a specific position. Returns True on success.
 func (b *Bot) SetStickerPositionInSet(sticker string, position int) (bool, error) {
-	src, err := b.marshler.Marshal(&SetStickerPositionInSet{
+	return b.SetStickerPositionInSetContext(context.Background(), sticker, position)
+}
+
+// SetStickerPositionInSetContext is the same as SetStickerPositionInSet, but with a context.Context.
+func (b *Bot) SetStickerPositionInSetContext(ctx context.Context, sticker string, position int) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodSetStickerPositionInSet, SetStickerPositionInSet{
 		Sticker:  sticker,
 		Position: position,
 	})
@@ -364,11 +361,6 @@ func (b *Bot) SetStickerPositionInSet(sticker string, position int) (bool, error
 		return false, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return false, err
-	}
-
 	var result bool
 	if err = b.marshler.Unmarshal(resp.Result, &result); err != nil {
 		return false, err
@@ -379,13 +371,13 @@ func (b *Bot) SetStickerPositionInSet(sticker string, position int) (bool, error
 
 // DeleteStickerFromSet delete a sticker from a set created by the b. Returns True on success.
 func (b *Bot) DeleteStickerFromSet(sticker string) (bool, error) {
-	src, err := b.Do(MethodDeleteStickerFromSet, DeleteStickerFromSet{Sticker: sticker})
-	if err != nil {
-		return false, err
-	}
+	return b.DeleteStickerFromSetContext(context.Background(), sticker)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// DeleteStickerFromSetContext is the same as DeleteStickerFromSet, but with a context.Context.
+func (b *Bot) DeleteStickerFromSetContext(ctx context.Context, sticker string) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodDeleteStickerFromSet, DeleteStickerFromSet{Sticker: sticker})
+	if err != nil {
 		return false, err
 	}
 
@@ -400,30 +392,21 @@ func (b *Bot) DeleteStickerFromSet(sticker string) (bool, error) {
 // SetStickerSetThumb set the thumbnail of a sticker set. Animated thumbnails can be set for animated sticker sets
 // only. Returns True on success.
 func (b *Bot) SetStickerSetThumb(p SetStickerSetThumb) (bool, error) {
+	return b.SetStickerSetThumbContext(context.Background(), p)
+}
+
+// SetStickerSetThumbContext is the same as SetStickerSetThumb, but with a context.Context.
+func (b *Bot) SetStickerSetThumbContext(ctx context.Context, p SetStickerSetThumb) (bool, error) {
 	params := make(map[string]string)
 	params["name"] = p.Name
 	params["user_id"] = strconv.Itoa(p.UserID)
 
-	var err error
-	if params["thumb"], err = b.marshler.MarshalToString(p.Thumb); err != nil {
-		return false, err
-	}
-
-	files := make([]*InputFile, 0)
-	if p.Thumb.IsAttachment() {
-		files = append(files, p.Thumb)
-	}
-
-	src, err := b.Upload(MethodSetStickerSetThumb, params, files...)
+	parts := attachFiles(params, map[string]*InputFile{"thumb": p.Thumb})
+	resp, err := b.UploadContext(ctx, MethodSetStickerSetThumb, params, parts...)
 	if err != nil {
 		return false, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
-		return false, err
-	}
-
 	var result bool
 	if err = b.marshler.Unmarshal(resp.Result, &result); err != nil {
 		return false, err
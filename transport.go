@@ -0,0 +1,200 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	nethttp "net/http"
+
+	http "github.com/valyala/fasthttp"
+)
+
+type (
+	// Transport executes HTTP requests to the Bot API server.
+	Transport interface {
+		// Do executes the request and returns the response. The response body must be closed by caller.
+		// Cancellation of ctx must interrupt waiting for the response.
+		Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error)
+	}
+
+	// TransportRequest represents an outgoing HTTP request.
+	TransportRequest struct {
+		// Method is a HTTP method, POST is used if it's empty.
+		Method string
+
+		// URI is an absolute request URI.
+		URI string
+
+		// ContentType of Body, if any.
+		ContentType string
+
+		// Body is an optional request body.
+		Body io.Reader
+
+		// ContentLength is the size of Body or -1 if it's unknown.
+		ContentLength int64
+	}
+
+	// TransportResponse represents an incoming HTTP response.
+	TransportResponse struct {
+		// StatusCode is a HTTP status code of the response.
+		StatusCode int
+
+		// Body of the response. It's never nil.
+		Body io.ReadCloser
+
+		// ContentLength is the size of Body or -1 if it's unknown.
+		ContentLength int64
+	}
+
+	// FastHTTPTransport is a Transport based on fasthttp.Client. It's used by default.
+	//
+	// fasthttp can't abort a request on ctx cancellation: it keeps running in background until the ctx deadline,
+	// if any, so the request can still reach the server and take effect (for example, the message can be sent).
+	// Use ctx with a deadline to bound it or NetHTTPTransport which aborts requests on cancellation.
+	FastHTTPTransport struct {
+		client *http.Client
+	}
+
+	// NetHTTPTransport is a Transport based on net/http.Client. Use it for proxies, tracing round-trippers, mTLS
+	// and other net/http infrastructure.
+	NetHTTPTransport struct {
+		client *nethttp.Client
+	}
+)
+
+// userAgent is sent by transports with each request.
+const userAgent string = "toby3d/telegram"
+
+// NewFastHTTPTransport creates a new Transport based on provided fasthttp.Client. A new client is used if it's nil.
+func NewFastHTTPTransport(client *http.Client) *FastHTTPTransport {
+	if client == nil {
+		client = new(http.Client)
+	}
+
+	return &FastHTTPTransport{client: client}
+}
+
+// NewNetHTTPTransport creates a new Transport based on provided net/http.Client. The http.DefaultClient is used if
+// it's nil.
+func NewNetHTTPTransport(client *nethttp.Client) *NetHTTPTransport {
+	if client == nil {
+		client = nethttp.DefaultClient
+	}
+
+	return &NetHTTPTransport{client: client}
+}
+
+// SetTransport allow set custom Transport for all requests to the Bot API server.
+func (b *Bot) SetTransport(t Transport) {
+	if b == nil {
+		b = new(Bot)
+	}
+
+	b.transport = t
+}
+
+// Do executes the request by fasthttp.Client. On ctx cancellation the request is not aborted, but abandoned and
+// released in background after the client returns. The ctx deadline is passed to the client as is, so the request
+// ends not later than ctx.
+func (t *FastHTTPTransport) Do(ctx context.Context, r *TransportRequest) (*TransportResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	req := http.AcquireRequest()
+	req.Header.SetUserAgent(userAgent)
+	req.Header.SetMethod(http.MethodPost)
+	req.SetRequestURI(r.URI)
+
+	if r.Method != "" {
+		req.Header.SetMethod(r.Method)
+	}
+
+	if r.ContentType != "" {
+		req.Header.SetContentType(r.ContentType)
+	}
+
+	if r.Body != nil {
+		req.SetBodyStream(r.Body, int(r.ContentLength))
+	}
+
+	resp := http.AcquireResponse()
+	done := make(chan error, 1)
+
+	go func() {
+		if deadline, ok := ctx.Deadline(); ok {
+			done <- t.client.DoDeadline(req, resp, deadline)
+		} else {
+			done <- t.client.Do(req, resp)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		go func() {
+			<-done
+			http.ReleaseRequest(req)
+			http.ReleaseResponse(resp)
+		}()
+
+		return nil, ctx.Err()
+	case err := <-done:
+		defer http.ReleaseRequest(req)
+		defer http.ReleaseResponse(resp)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Body of response is owned by fasthttp and must be copied before release.
+		body := append([]byte(nil), resp.Body()...)
+
+		return &TransportResponse{
+			StatusCode:    resp.StatusCode(),
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+		}, nil
+	}
+}
+
+// Do executes the request by net/http.Client.
+func (t *NetHTTPTransport) Do(ctx context.Context, r *TransportRequest) (*TransportResponse, error) {
+	method := r.Method
+	if method == "" {
+		method = nethttp.MethodPost
+	}
+
+	req, err := nethttp.NewRequest(method, r.URI, r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", userAgent)
+
+	if r.ContentType != "" {
+		req.Header.Set("Content-Type", r.ContentType)
+	}
+
+	if r.Body != nil && r.ContentLength >= 0 {
+		req.ContentLength = r.ContentLength
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		// net/http wraps ctx errors into url.Error, but callers expect them as is.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		return nil, err
+	}
+
+	return &TransportResponse{
+		StatusCode:    resp.StatusCode,
+		Body:          resp.Body,
+		ContentLength: resp.ContentLength,
+	}, nil
+}
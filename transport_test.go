@@ -0,0 +1,57 @@
+package telegram
+
+import (
+	"context"
+	"io/ioutil"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetHTTPTransport(t *testing.T) {
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		assert.Equal(t, nethttp.MethodPost, r.Method)
+		assert.Equal(t, "/bot123:abc/getMe", r.URL.Path)
+		assert.Equal(t, userAgent, r.UserAgent())
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"id":42,"is_bot":true,"first_name":"Test"}}`))
+	}))
+	defer srv.Close()
+
+	b := &Bot{AccessToken: "123:abc", marshler: json.ConfigFastest}
+	b.SetEndpoint("http", srv.Listener.Addr().String(), "")
+	b.SetTransport(NewNetHTTPTransport(srv.Client()))
+
+	t.Run("ok", func(t *testing.T) {
+		u, err := b.GetMe()
+		assert.NoError(t, err)
+		assert.Equal(t, 42, u.ID)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := b.GetMeContext(ctx)
+		assert.Equal(t, context.Canceled, err)
+	})
+	t.Run("raw", func(t *testing.T) {
+		resp, err := NewNetHTTPTransport(nil).Do(context.Background(), &TransportRequest{
+			URI:           srv.URL + "/bot123:abc/getMe",
+			ContentType:   "application/json",
+			ContentLength: -1,
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, nethttp.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), `"ok":true`)
+	})
+}
@@ -1,9 +1,11 @@
 package telegram
 
 import (
+	"bytes"
 	"encoding/json"
-	"os"
+	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,11 +16,11 @@ import (
 type (
 	// Response represents a response from the Telegram API with the result  stored raw. If ok equals true, the request was successful, and the result  of the query can be found in the result field. In case of an unsuccessful  request, ok equals false, and the error is explained in the error field.
 	Response struct {
-		Description string                `json:"description,omitempty"`
-		ErrorCode   int                   `json:"error_code,omitempty"`
-		Ok          bool                  `json:"ok"`
-		Parameters  []*ResponseParameters `json:"parameters,omitempty"`
-		Result      json.RawMessage       `json:"result,omitempty"`
+		Description string              `json:"description,omitempty"`
+		ErrorCode   int                 `json:"error_code,omitempty"`
+		Ok          bool                `json:"ok"`
+		Parameters  *ResponseParameters `json:"parameters,omitempty"`
+		Result      json.RawMessage     `json:"result,omitempty"`
 	}
 
 	// User represents a Telegram user or bot.
@@ -928,9 +930,22 @@ type (
 
 	// InputFile represents the contents of a file to be uploaded. Must be poste using multipart/form-data in the usual way that files are uploaded via the browser.
 	InputFile struct {
-		ID         string    `json:"-"`
-		URI        *http.URI `json:"-"`
-		Attachment *os.File  `json:"-"`
+		ID  string    `json:"-"`
+		URI *http.URI `json:"-"`
+
+		// Attachment is a content of the uploaded file. Any io.Reader can be used, but only io.Seeker can be read
+		// again if the request must be repeated with a migrated chat.
+		Attachment io.Reader `json:"-"`
+
+		// Name of the uploaded file. Attachment.Name() is used if it's empty and Attachment is an *os.File.
+		Name string `json:"-"`
+
+		// Size of the uploaded file in bytes, if known.
+		Size int64 `json:"-"`
+
+		// CacheKey identifies the content of Attachment in the upload cache of Bot. SHA-256 hash of content is used
+		// if it's empty.
+		CacheKey string `json:"-"`
 	}
 
 	Photo []*PhotoSize
@@ -1403,6 +1418,43 @@ func (p Poll) VotesCount() int {
 	return v
 }
 
+// File returns File structure without FilePath parameter.
+func (ps PhotoSize) File() File {
+	return File{
+		FileID:       ps.FileID,
+		FileUniqueID: ps.FileUniqueID,
+		FileSize:     ps.FileSize,
+	}
+}
+
+// Largest returns the biggest size of the photo by dimensions or nil if photo is empty.
+func (p Photo) Largest() *PhotoSize {
+	var result *PhotoSize
+
+	for i := range p {
+		if p[i] != nil && (result == nil || p[i].area() > result.area()) {
+			result = p[i]
+		}
+	}
+
+	return result
+}
+
+// Smallest returns the smallest size of the photo by dimensions or nil if photo is empty.
+func (p Photo) Smallest() *PhotoSize {
+	var result *PhotoSize
+
+	for i := range p {
+		if p[i] != nil && (result == nil || p[i].area() < result.area()) {
+			result = p[i]
+		}
+	}
+
+	return result
+}
+
+func (ps PhotoSize) area() int { return ps.Width * ps.Height }
+
 func (p ChatPhoto) SmallFile() File {
 	return File{
 		FileID:       p.SmallFileID,
@@ -1449,31 +1501,59 @@ func (m *InputMediaVideo) GetMedia() *InputFile { return m.Media }
 
 func (InputMediaVideo) isAlbumMedia() {}
 
+// NewInputFileReader creates a new InputFile for upload the content of r with the file name.
+func NewInputFileReader(name string, r io.Reader) *InputFile {
+	return &InputFile{Attachment: r, Name: name}
+}
+
+// NewInputFileBytes creates a new InputFile for upload src with the file name.
+func NewInputFileBytes(name string, src []byte) *InputFile {
+	return &InputFile{Attachment: bytes.NewReader(src), Name: name, Size: int64(len(src))}
+}
+
+// NewInputFileID creates a new InputFile for send the file which exists on the Telegram servers.
+func NewInputFileID(id string) *InputFile {
+	return &InputFile{ID: id}
+}
+
+// NewInputFileURL creates a new InputFile for send the file from the Internet by the URL.
+func NewInputFileURL(u string) *InputFile {
+	uri := new(http.URI)
+	uri.Update(u)
+
+	return &InputFile{URI: uri}
+}
+
 func (f InputFile) IsFileID() bool { return f.ID != "" }
 
 func (f InputFile) IsURI() bool { return f.URI != nil }
 
 func (f InputFile) IsAttachment() bool { return f.Attachment != nil }
 
+// MarshalJSON encodes the file as JSON string with file ID, URL or "attach://<FileName>" reference. Empty file is
+// encoded as null.
 func (f InputFile) MarshalJSON() ([]byte, error) {
 	switch {
 	case f.IsFileID():
-		return []byte(f.ID), nil
+		return []byte(strconv.Quote(f.ID)), nil
 	case f.IsURI():
-		return f.URI.FullURI(), nil
+		return []byte(strconv.Quote(f.URI.String())), nil
 	case f.IsAttachment():
-		_, fileName := filepath.Split(f.Attachment.Name())
-
-		u := http.AcquireURI()
-		defer http.ReleaseURI(u)
-		u.SetScheme(SchemeAttach)
-		u.SetHost(fileName)
-		u.SetPathBytes(nil)
+		return []byte(strconv.Quote(SchemeAttach + "://" + f.FileName())), nil
+	default:
+		return []byte("null"), nil
+	}
+}
 
-		uri := u.FullURI() // NOTE(toby3d): remove slash on the end
+// FileName returns the name of the uploaded file.
+func (f InputFile) FileName() string {
+	if f.Name != "" {
+		return f.Name
+	}
 
-		return uri[:len(uri)-1], nil
-	default:
-		return nil, nil
+	if named, ok := f.Attachment.(interface{ Name() string }); ok {
+		return filepath.Base(named.Name())
 	}
+
+	return ""
 }
@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -2104,19 +2105,19 @@ func TestInputFileMarshalJSON(t *testing.T) {
 	}{{
 		name:      "id",
 		inputFile: InputFile{ID: "abc"},
-		expResult: "abc",
+		expResult: `"abc"`,
 	}, {
 		name:      "uri",
 		inputFile: InputFile{URI: u},
-		expResult: u.String(),
+		expResult: `"` + u.String() + `"`,
 	}, {
 		name:      "attach",
 		inputFile: InputFile{Attachment: file},
-		expResult: SchemeAttach + "://" + fileName,
+		expResult: `"` + SchemeAttach + "://" + fileName + `"`,
 	}, {
 		name:      "empty",
 		inputFile: InputFile{},
-		expResult: "",
+		expResult: "null",
 	}} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -2126,3 +2127,54 @@ func TestInputFileMarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNewInputFile(t *testing.T) {
+	t.Run("reader", func(t *testing.T) {
+		f := NewInputFileReader("photo.jpg", strings.NewReader("abc"))
+		assert.True(t, f.IsAttachment())
+		assert.Equal(t, "photo.jpg", f.FileName())
+		assert.Zero(t, f.Size)
+	})
+	t.Run("bytes", func(t *testing.T) {
+		f := NewInputFileBytes("photo.jpg", []byte("abc"))
+		assert.True(t, f.IsAttachment())
+		assert.Equal(t, int64(3), f.Size)
+
+		src, err := f.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, `"`+SchemeAttach+`://photo.jpg"`, string(src))
+	})
+	t.Run("id", func(t *testing.T) {
+		assert.True(t, NewInputFileID("abc").IsFileID())
+	})
+	t.Run("url", func(t *testing.T) {
+		f := NewInputFileURL("https://toby3d.me/image.jpeg")
+		assert.True(t, f.IsURI())
+		assert.Equal(t, "https://toby3d.me/image.jpeg", f.URI.String())
+	})
+}
+
+func TestInputFileFileName(t *testing.T) {
+	file, err := ioutil.TempFile(os.TempDir(), "photo_*.jpeg")
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(file.Name())
+
+	f := InputFile{Attachment: file}
+	assert.Equal(t, filepath.Base(file.Name()), f.FileName())
+
+	f.Name = "custom.jpeg"
+	assert.Equal(t, "custom.jpeg", f.FileName())
+}
+
+func TestPhotoLargest(t *testing.T) {
+	small := &PhotoSize{FileID: "small", Width: 90, Height: 51}
+	medium := &PhotoSize{FileID: "medium", Width: 320, Height: 180}
+	large := &PhotoSize{FileID: "large", Width: 1280, Height: 720}
+
+	assert.Equal(t, large, Photo{small, large, medium}.Largest())
+	assert.Equal(t, small, Photo{medium, small, large}.Smallest())
+	assert.Nil(t, Photo{}.Largest())
+	assert.Nil(t, Photo{}.Smallest())
+	assert.Equal(t, "large", large.File().FileID)
+}
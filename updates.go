@@ -1,6 +1,8 @@
 package telegram
 
 import (
+	"context"
+	"strconv"
 	"time"
 
 	http "github.com/valyala/fasthttp"
@@ -96,7 +98,7 @@ type (
 		URL string `json:"url"`
 
 		// Upload your public key certificate so that the root certificate in use can be checked. See our self-signed guide for details.
-		Certificate InputFile `json:"certificate,omitempty"`
+		Certificate InputFile `json:"-"`
 
 		// Maximum allowed number of simultaneous HTTPS connections to the webhook for update delivery, 1-100. Defaults to 40. Use lower values to limit the load on your bot‘s server, and higher values to increase your bot’s throughput.
 		MaxConnections int `json:"max_connections,omitempty"`
@@ -110,13 +112,13 @@ type (
 
 // GetUpdates receive incoming updates using long polling. An Array of Update objects is returned.
 func (b Bot) GetUpdates(p *GetUpdates) ([]*Update, error) {
-	src, err := b.Do(MethodGetUpdates, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.GetUpdatesContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetUpdatesContext is the same as GetUpdates, but with a context.Context.
+func (b Bot) GetUpdatesContext(ctx context.Context, p *GetUpdates) ([]*Update, error) {
+	resp, err := b.DoContext(ctx, MethodGetUpdates, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -132,17 +134,23 @@ func (b Bot) GetUpdates(p *GetUpdates) ([]*Update, error) {
 //
 // If you'd like to make sure that the Webhook request comes from Telegram, we recommend using a secret path in the URL, e.g. https://www.example.com/<token>. Since nobody else knows your bot‘s token, you can be pretty sure it’s us.
 func (b Bot) SetWebhook(p SetWebhook) (bool, error) {
-	// TODO(toby3d)
-	// if p.Certificate != nil {
-	// 	src, err = b.Upload(MethodSetWebhook, "certificate", "cert.pem", params.Certificate, args)
-	// }
-	src, err := b.Do(MethodSetWebhook, p)
-	if err != nil {
-		return false, err
+	return b.SetWebhookContext(context.Background(), p)
+}
+
+// SetWebhookContext is the same as SetWebhook, but with a context.Context.
+func (b Bot) SetWebhookContext(ctx context.Context, p SetWebhook) (bool, error) {
+	var (
+		resp *Response
+		err  error
+	)
+
+	if p.Certificate.IsAttachment() {
+		resp, err = b.uploadWebhook(ctx, p)
+	} else {
+		resp, err = b.DoContext(ctx, MethodSetWebhook, p)
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+	if err != nil {
 		return false, err
 	}
 
@@ -154,15 +162,36 @@ func (b Bot) SetWebhook(p SetWebhook) (bool, error) {
 	return result, nil
 }
 
+// uploadWebhook sends SetWebhook parameters with certificate as multipart/form-data.
+func (b Bot) uploadWebhook(ctx context.Context, p SetWebhook) (*Response, error) {
+	params := make(map[string]string)
+	params["url"] = p.URL
+
+	if p.MaxConnections > 0 {
+		params["max_connections"] = strconv.Itoa(p.MaxConnections)
+	}
+
+	if p.AllowedUpdates != nil {
+		var err error
+		if params["allowed_updates"], err = b.marshler.MarshalToString(p.AllowedUpdates); err != nil {
+			return nil, err
+		}
+	}
+
+	parts := attachFiles(params, map[string]*InputFile{"certificate": &p.Certificate})
+
+	return b.UploadContext(ctx, MethodSetWebhook, params, parts...)
+}
+
 // DeleteWebhook remove webhook integration if you decide to switch back to getUpdates. Returns True on success. Requires no parameters.
 func (b Bot) DeleteWebhook() (bool, error) {
-	src, err := b.Do(MethodDeleteWebhook, nil)
-	if err != nil {
-		return false, err
-	}
+	return b.DeleteWebhookContext(context.Background())
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// DeleteWebhookContext is the same as DeleteWebhook, but with a context.Context.
+func (b Bot) DeleteWebhookContext(ctx context.Context) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodDeleteWebhook, nil)
+	if err != nil {
 		return false, err
 	}
 
@@ -176,13 +205,13 @@ func (b Bot) DeleteWebhook() (bool, error) {
 
 // GetWebhookInfo get current webhook status. Requires no parameters. On success, returns a WebhookInfo object. If the bot is using getUpdates, will return an object with the url field empty.
 func (b Bot) GetWebhookInfo() (*WebhookInfo, error) {
-	src, err := b.Do(MethodGetWebhookInfo, nil)
-	if err != nil {
-		return nil, err
-	}
+	return b.GetWebhookInfoContext(context.Background())
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// GetWebhookInfoContext is the same as GetWebhookInfo, but with a context.Context.
+func (b Bot) GetWebhookInfoContext(ctx context.Context) (*WebhookInfo, error) {
+	resp, err := b.DoContext(ctx, MethodGetWebhookInfo, nil)
+	if err != nil {
 		return nil, err
 	}
 
@@ -224,6 +253,60 @@ func (u Update) IsPreCheckoutQuery() bool { return u.PreCheckoutQuery != nil }
 // IsPoll checks that the current update is a poll update.
 func (u Update) IsPoll() bool { return u.Poll != nil }
 
+// IsPollAnswer checks that the current update is a poll answer update.
+func (u Update) IsPollAnswer() bool { return u.PollAnswer != nil }
+
+// EffectiveMessage returns the message of the update: new or edited message, channel post or message of the callback query.
+func (u Update) EffectiveMessage() *Message {
+	switch {
+	case u.IsMessage():
+		return u.Message
+	case u.IsEditedMessage():
+		return u.EditedMessage
+	case u.IsChannelPost():
+		return u.ChannelPost
+	case u.IsEditedChannelPost():
+		return u.EditedChannelPost
+	case u.IsCallbackQuery():
+		return u.CallbackQuery.Message
+	default:
+		return nil
+	}
+}
+
+// EffectiveUser returns the sender of the update, if any.
+func (u Update) EffectiveUser() *User {
+	switch {
+	case u.IsInlineQuery():
+		return u.InlineQuery.From
+	case u.IsChosenInlineResult():
+		return u.ChosenInlineResult.From
+	case u.IsCallbackQuery():
+		return u.CallbackQuery.From
+	case u.IsShippingQuery():
+		return u.ShippingQuery.From
+	case u.IsPreCheckoutQuery():
+		return u.PreCheckoutQuery.From
+	case u.IsPollAnswer():
+		return u.PollAnswer.User
+	}
+
+	if m := u.EffectiveMessage(); m != nil {
+		return m.From
+	}
+
+	return nil
+}
+
+// EffectiveChat returns the chat of the update message, if any.
+func (u Update) EffectiveChat() *Chat {
+	if m := u.EffectiveMessage(); m != nil {
+		return m.Chat
+	}
+
+	return nil
+}
+
 // Type return update type for current update.
 func (u Update) Type() string {
 	switch {
@@ -247,6 +330,8 @@ func (u Update) Type() string {
 		return UpdateShippingQuery
 	case u.IsPoll():
 		return UpdatePoll
+	case u.IsPollAnswer():
+		return UpdatePollAnswer
 	default:
 		return ""
 	}
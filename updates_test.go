@@ -1,6 +1,9 @@
 package telegram
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -118,6 +121,17 @@ func TestUpdateIsPoll(t *testing.T) {
 	})
 }
 
+func TestUpdateIsPollAnswer(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		u := Update{PollAnswer: &PollAnswer{PollID: "abc"}}
+		assert.True(t, u.IsPollAnswer())
+	})
+	t.Run("false", func(t *testing.T) {
+		u := Update{}
+		assert.False(t, u.IsPollAnswer())
+	})
+}
+
 func TestUpdateType(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
@@ -155,6 +169,10 @@ func TestUpdateType(t *testing.T) {
 		name:      UpdatePoll,
 		update:    Update{Poll: &Poll{ID: "abc"}},
 		expResult: UpdatePoll,
+	}, {
+		name:      UpdatePollAnswer,
+		update:    Update{PollAnswer: &PollAnswer{PollID: "abc"}},
+		expResult: UpdatePollAnswer,
 	}, {
 		name:      UpdatePreCheckoutQuery,
 		update:    Update{PreCheckoutQuery: &PreCheckoutQuery{ID: "abc"}},
@@ -206,3 +224,57 @@ func TestWebhookInfoURI(t *testing.T) {
 		assert.Nil(t, wi.URI())
 	})
 }
+
+func TestBotSetWebhook(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			assert.Equal(t, "application/json", string(ctx.Request.Header.ContentType()))
+			assert.JSONEq(t, `{"url":"https://example.com/hook","max_connections":10}`, string(ctx.PostBody()))
+			ctx.SetBodyString(`{"ok":true,"result":true}`)
+		})
+		defer stop()
+
+		ok, err := b.SetWebhook(SetWebhook{URL: "https://example.com/hook", MaxConnections: 10})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+	t.Run("certificate", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer os.RemoveAll(dir)
+
+		certFile := filepath.Join(dir, "cert.pem")
+		if !assert.NoError(t, WriteCertificate(certFile, filepath.Join(dir, "cert.key"), "203.0.113.1",
+			time.Hour)) {
+			t.FailNow()
+		}
+
+		cert, err := os.Open(certFile)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer cert.Close()
+
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			form, err := ctx.MultipartForm()
+			if assert.NoError(t, err) {
+				assert.Equal(t, []string{"https://203.0.113.1/hook"}, form.Value["url"])
+				assert.Equal(t, []string{`["message"]`}, form.Value["allowed_updates"])
+				assert.Len(t, form.File, 1)
+			}
+
+			ctx.SetBodyString(`{"ok":true,"result":true}`)
+		})
+		defer stop()
+
+		ok, err := b.SetWebhook(SetWebhook{
+			URL:            "https://203.0.113.1/hook",
+			Certificate:    InputFile{Attachment: cert},
+			AllowedUpdates: []string{UpdateMessage},
+		})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
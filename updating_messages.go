@@ -1,5 +1,10 @@
 package telegram
 
+import (
+	"context"
+	"strconv"
+)
+
 type (
 	// EditMessageTextParameters represents data for EditMessageText method.
 	EditMessageText struct {
@@ -106,13 +111,13 @@ func NewEditText(text string) EditMessageText {
 
 // EditMessageText edit text and game messages sent by the bot or via the bot (for inline bots). On success, if edited message is sent by the bot, the edited Message is returned, otherwise True is returned.
 func (b Bot) EditMessageText(p *EditMessageText) (*Message, error) {
-	src, err := b.Do(MethodEditMessageText, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.EditMessageTextContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// EditMessageTextContext is the same as EditMessageText, but with a context.Context.
+func (b Bot) EditMessageTextContext(ctx context.Context, p *EditMessageText) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodEditMessageText, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -126,13 +131,13 @@ func (b Bot) EditMessageText(p *EditMessageText) (*Message, error) {
 
 // EditMessageCaption edit captions of messages sent by the bot or via the bot (for inline bots). On success, if edited message is sent by the bot, the edited Message is returned, otherwise True is returned.
 func (b Bot) EditMessageCaption(p *EditMessageCaption) (*Message, error) {
-	src, err := b.Do(MethodEditMessageCaption, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.EditMessageCaptionContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// EditMessageCaptionContext is the same as EditMessageCaption, but with a context.Context.
+func (b Bot) EditMessageCaptionContext(ctx context.Context, p *EditMessageCaption) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodEditMessageCaption, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -150,13 +155,46 @@ func NewEditMedia(media InputMedia) EditMessageMedia {
 
 // EditMessageMedia edit audio, document, photo, or video messages. If a message is a part of a message album, then it can be edited only to a photo or a video. Otherwise, message type can be changed arbitrarily. When inline message is edited, new file can't be uploaded. Use previously uploaded file via its file_id or specify a URL. On success, if the edited message was sent by the bot, the edited Message is returned, otherwise True is returned.
 func (b Bot) EditMessageMedia(p EditMessageMedia) (*Message, error) {
-	src, err := b.Do(MethodEditMessageMedia, p)
+	return b.EditMessageMediaContext(context.Background(), p)
+}
+
+// EditMessageMediaContext is the same as EditMessageMedia, but with a context.Context.
+func (b Bot) EditMessageMediaContext(ctx context.Context, p EditMessageMedia) (*Message, error) {
+	media, parts, err := b.attachMedia(p.Media, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+	var resp *Response
+
+	if len(parts) == 0 {
+		resp, err = b.DoContext(ctx, MethodEditMessageMedia, p)
+	} else {
+		params := make(map[string]string)
+		params["media"] = string(media)
+
+		if p.ChatID != 0 {
+			params["chat_id"] = strconv.FormatInt(p.ChatID, 10)
+		}
+
+		if p.MessageID != 0 {
+			params["message_id"] = strconv.Itoa(p.MessageID)
+		}
+
+		if p.InlineMessageID != "" {
+			params["inline_message_id"] = p.InlineMessageID
+		}
+
+		if p.ReplyMarkup != nil {
+			if params["reply_markup"], err = b.marshler.MarshalToString(p.ReplyMarkup); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err = b.UploadContext(ctx, MethodEditMessageMedia, params, parts...)
+	}
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -170,13 +208,13 @@ func (b Bot) EditMessageMedia(p EditMessageMedia) (*Message, error) {
 
 // EditMessageReplyMarkup edit only the reply markup of messages sent by the bot or via the bot (for inline bots). On success, if edited message is sent by the bot, the edited Message is returned, otherwise True is returned.
 func (b Bot) EditMessageReplyMarkup(p EditMessageReplyMarkup) (*Message, error) {
-	src, err := b.Do(MethodEditMessageReplyMarkup, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.EditMessageReplyMarkupContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// EditMessageReplyMarkupContext is the same as EditMessageReplyMarkup, but with a context.Context.
+func (b Bot) EditMessageReplyMarkupContext(ctx context.Context, p EditMessageReplyMarkup) (*Message, error) {
+	resp, err := b.DoContext(ctx, MethodEditMessageReplyMarkup, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -194,13 +232,13 @@ func NewStopPoll(chatID int64, messageID int) StopPoll {
 
 // StopPoll stop a poll which was sent by the bot. On success, the stopped Poll with the final results is returned.
 func (b Bot) StopPoll(p StopPoll) (*Poll, error) {
-	src, err := b.Do(MethodStopPoll, p)
-	if err != nil {
-		return nil, err
-	}
+	return b.StopPollContext(context.Background(), p)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// StopPollContext is the same as StopPoll, but with a context.Context.
+func (b Bot) StopPollContext(ctx context.Context, p StopPoll) (*Poll, error) {
+	resp, err := b.DoContext(ctx, MethodStopPoll, p)
+	if err != nil {
 		return nil, err
 	}
 
@@ -223,13 +261,13 @@ func (b Bot) StopPoll(p StopPoll) (*Poll, error) {
 //
 // Returns True on success.
 func (b Bot) DeleteMessage(cid int64, mid int) (bool, error) {
-	src, err := b.Do(MethodDeleteMessage, DeleteMessage{ChatID: cid, MessageID: mid})
-	if err != nil {
-		return false, err
-	}
+	return b.DeleteMessageContext(context.Background(), cid, mid)
+}
 
-	resp := new(Response)
-	if err = b.marshler.Unmarshal(src, resp); err != nil {
+// DeleteMessageContext is the same as DeleteMessage, but with a context.Context.
+func (b Bot) DeleteMessageContext(ctx context.Context, cid int64, mid int) (bool, error) {
+	resp, err := b.DoContext(ctx, MethodDeleteMessage, DeleteMessage{ChatID: cid, MessageID: mid})
+	if err != nil {
 		return false, err
 	}
 
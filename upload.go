@@ -0,0 +1,320 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"os"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+type (
+	// UploadPart is a file of multipart/form-data body uploaded under the Field name. The Field is a name of method
+	// parameter (like "photo" or "thumb") or a name referenced by "attach://<Field>" in the other parameters.
+	UploadPart struct {
+		Field string
+		File  *InputFile
+	}
+
+	// UploadProgress reports the number of sent bytes of the multipart/form-data body. The total is -1 if the size
+	// of the body is unknown.
+	UploadProgress func(sent, total int64)
+
+	// upload streams multipart/form-data body with files for each attempt of the request.
+	upload struct {
+		payload  map[string]string
+		parts    []UploadPart
+		offsets  []int64
+		boundary string
+		progress UploadProgress
+		body     *io.PipeReader
+		done     chan struct{}
+	}
+
+	// progressWriter counts bytes written into the body and reports them.
+	progressWriter struct {
+		w        io.Writer
+		sent     int64
+		total    int64
+		progress UploadProgress
+	}
+
+	// pipeWriter drops empty writes, because pipe passes them to the reader as empty reads, which fasthttp rejects
+	// while streaming body of unknown length.
+	pipeWriter struct {
+		w io.Writer
+	}
+
+	uploadProgressKey struct{}
+)
+
+// errNotRepeatable describes an upload which cannot be sent again because some files are not io.Seeker.
+var errNotRepeatable = xerrors.New("upload cannot be repeated with not seekable files") //nolint: gochecknoglobals
+
+// WithUploadProgress returns a copy of ctx with progress callback which is called by UploadContext while the body of
+// request is sent.
+func WithUploadProgress(ctx context.Context, progress UploadProgress) context.Context {
+	return context.WithValue(ctx, uploadProgressKey{}, progress)
+}
+
+// newUpload remembers current positions of files for rewinding them if the form must be written again. Positions of
+// not seekable files (including pipes and sockets which fail on seek) are -1.
+func newUpload(ctx context.Context, payload map[string]string, parts []UploadPart) *upload {
+	u := &upload{
+		payload:  payload,
+		parts:    parts,
+		offsets:  make([]int64, len(parts)),
+		boundary: multipart.NewWriter(nil).Boundary(),
+	}
+	u.progress, _ = ctx.Value(uploadProgressKey{}).(UploadProgress)
+
+	for i := range parts {
+		u.offsets[i] = -1
+
+		s, ok := parts[i].File.Attachment.(io.Seeker)
+		if !ok {
+			continue
+		}
+
+		if offset, err := s.Seek(0, io.SeekCurrent); err == nil {
+			u.offsets[i] = offset
+		}
+	}
+
+	return u
+}
+
+// repeatable checks that all files can be read again.
+func (u *upload) repeatable() bool {
+	for i := range u.offsets {
+		if u.offsets[i] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// build sets streamed body of the form into req. Files are rewound before each attempt except the first one.
+//
+// The form is written by background goroutine into pipe, so the whole files are never kept in memory.
+// Previous body is closed and waited before rewinding, because transport can still read it.
+func (u *upload) build(req *TransportRequest) error {
+	if u.done != nil {
+		u.close()
+
+		if !u.repeatable() {
+			return errNotRepeatable
+		}
+
+		for i := range u.parts {
+			if _, err := u.parts[i].File.Attachment.(io.Seeker).Seek(u.offsets[i], io.SeekStart); err != nil {
+				return err
+			}
+		}
+	}
+
+	size, err := u.size()
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	u.body, u.done = pr, make(chan struct{})
+
+	go func(done chan struct{}) {
+		defer close(done)
+
+		var w io.Writer = pipeWriter{w: pw}
+		if u.progress != nil {
+			w = &progressWriter{w: w, total: size, progress: u.progress}
+		}
+
+		_ = pw.CloseWithError(writeForm(w, u.boundary, u.payload, u.parts, true))
+	}(u.done)
+
+	req.ContentType = "multipart/form-data; boundary=" + u.boundary
+	req.Body = pr
+	req.ContentLength = size
+
+	return nil
+}
+
+// close interrupts writing of the current body and waits for it.
+func (u *upload) close() {
+	if u.done == nil {
+		return
+	}
+
+	_ = u.body.Close()
+	<-u.done
+}
+
+// size returns the length of the form or -1 if the size of any file is unknown.
+func (u *upload) size() (int64, error) {
+	counter := new(progressWriter)
+	if err := writeForm(counter, u.boundary, u.payload, u.parts, false); err != nil {
+		return 0, err
+	}
+
+	for i := range u.parts {
+		size := u.parts[i].File.size(u.offsets[i])
+		if size < 0 {
+			return -1, nil
+		}
+
+		counter.sent += size
+	}
+
+	return counter.sent, nil
+}
+
+// size returns the number of bytes left in Attachment or -1 if it's unknown.
+func (f InputFile) size(offset int64) int64 {
+	if f.Size > 0 {
+		return f.Size
+	}
+
+	switch r := f.Attachment.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case interface{ Stat() (os.FileInfo, error) }:
+		info, err := r.Stat()
+		if err != nil || offset < 0 || !info.Mode().IsRegular() {
+			return -1
+		}
+
+		return info.Size() - offset
+	default:
+		return -1
+	}
+}
+
+// writeForm writes payload and parts into w as multipart/form-data with the boundary. Content of files is skipped if
+// withFiles is false.
+func writeForm(w io.Writer, boundary string, payload map[string]string, parts []UploadPart, withFiles bool) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	for i := range parts {
+		fileName := parts[i].File.FileName()
+		if fileName == "" {
+			fileName = parts[i].Field
+		}
+
+		part, err := mw.CreateFormFile(parts[i].Field, fileName)
+		if err != nil {
+			return err
+		}
+
+		if !withFiles {
+			continue
+		}
+
+		if _, err = io.Copy(part, parts[i].File.Attachment); err != nil {
+			return err
+		}
+	}
+
+	for key, val := range payload {
+		if err := mw.WriteField(key, val); err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
+// attachFiles adds files into params by keys as file IDs or URLs. Attachments are returned as parts named by keys.
+func attachFiles(params map[string]string, files map[string]*InputFile) []UploadPart {
+	parts := make([]UploadPart, 0, len(files))
+
+	for key, f := range files {
+		switch {
+		case f == nil:
+		case f.IsAttachment():
+			parts = append(parts, UploadPart{Field: key, File: f})
+		case f.IsFileID():
+			params[key] = f.ID
+		case f.IsURI():
+			params[key] = f.URI.String()
+		}
+	}
+
+	return parts
+}
+
+// attachMedia encodes media into JSON and appends its attachments to parts under unique "attach://fileN" names.
+// Names are replaced in encoded copy, so media can be safely reused.
+func (b Bot) attachMedia(m InputMedia, parts []UploadPart) ([]byte, []UploadPart, error) {
+	src, err := b.marshler.Marshal(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := map[string]*InputFile{"media": m.GetMedia()}
+
+	switch m := m.(type) {
+	case *InputMediaAnimation:
+		files["thumb"] = m.Thumb
+	case *InputMediaAudio:
+		files["thumb"] = m.Thumb
+	case *InputMediaDocument:
+		files["thumb"] = m.Thumb
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	for _, key := range []string{"media", "thumb"} {
+		if files[key] == nil || !files[key].IsAttachment() {
+			continue
+		}
+
+		if len(fields) == 0 {
+			if err = json.Unmarshal(src, &fields); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		name := "file" + strconv.Itoa(len(parts))
+		fields[key] = json.RawMessage(strconv.Quote(SchemeAttach + "://" + name))
+		parts = append(parts, UploadPart{Field: name, File: files[key]})
+	}
+
+	if len(fields) == 0 {
+		return src, parts, nil
+	}
+
+	if src, err = json.Marshal(fields); err != nil {
+		return nil, nil, err
+	}
+
+	return src, parts, nil
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	if w.w == nil {
+		w.sent += int64(len(p))
+
+		return len(p), nil
+	}
+
+	n, err := w.w.Write(p)
+	w.sent += int64(n)
+	w.progress(w.sent, w.total)
+
+	return n, err
+}
+
+func (w pipeWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	return w.w.Write(p)
+}
@@ -0,0 +1,254 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"golang.org/x/xerrors"
+)
+
+// readUpload returns the content of the single uploaded file.
+func readUpload(t *testing.T, ctx *http.RequestCtx) string {
+	form, err := ctx.MultipartForm()
+	if !assert.NoError(t, err) {
+		return ""
+	}
+
+	for _, headers := range form.File {
+		f, err := headers[0].Open()
+		if !assert.NoError(t, err) {
+			return ""
+		}
+
+		src, err := ioutil.ReadAll(f)
+		assert.NoError(t, err)
+		f.Close()
+
+		return string(src)
+	}
+
+	return ""
+}
+
+func TestBotUploadStream(t *testing.T) {
+	content := strings.Repeat("telegram", 1<<14)
+
+	t.Run("length", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			assert.Equal(t, len(ctx.PostBody()), ctx.Request.Header.ContentLength())
+			assert.Equal(t, content, readUpload(t, ctx))
+			ctx.SetBodyString(`{"ok":true,"result":true}`)
+		})
+		defer stop()
+
+		var sent, total int64
+
+		ctx := WithUploadProgress(context.Background(), func(s, t int64) {
+			atomic.StoreInt64(&sent, s)
+			atomic.StoreInt64(&total, t)
+		})
+
+		_, err := b.UploadContext(ctx, MethodSendDocument, map[string]string{"chat_id": "42"}, UploadPart{
+			Field: "document",
+			File:  NewInputFileBytes("file.txt", []byte(content)),
+		})
+		assert.NoError(t, err)
+		assert.True(t, atomic.LoadInt64(&total) > int64(len(content)))
+		assert.Equal(t, atomic.LoadInt64(&total), atomic.LoadInt64(&sent))
+	})
+	t.Run("unknown length", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			assert.Equal(t, content, readUpload(t, ctx))
+			ctx.SetBodyString(`{"ok":true,"result":true}`)
+		})
+		defer stop()
+
+		var (
+			mu     sync.Mutex
+			totals []int64
+		)
+
+		ctx := WithUploadProgress(context.Background(), func(_, t int64) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			totals = append(totals, t)
+		})
+
+		_, err := b.UploadContext(ctx, MethodSendDocument, nil, UploadPart{
+			Field: "document",
+			File:  NewInputFileReader("file.txt", io.MultiReader(strings.NewReader(content))),
+		})
+		assert.NoError(t, err)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if assert.NotEmpty(t, totals) {
+			assert.Equal(t, int64(-1), totals[0])
+		}
+	})
+	t.Run("pipe", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			assert.Equal(t, content, readUpload(t, ctx))
+			ctx.SetBodyString(`{"ok":true,"result":true}`)
+		})
+		defer stop()
+
+		r, w, err := os.Pipe()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer r.Close()
+
+		go func() {
+			_, _ = io.WriteString(w, content)
+			w.Close()
+		}()
+
+		_, err = b.Upload(MethodSendDocument, map[string]string{"caption": ""}, UploadPart{
+			Field: "document",
+			File:  NewInputFileReader("file.txt", r),
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("retry", func(t *testing.T) {
+		var attempts int32
+
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			assert.Equal(t, content, readUpload(t, ctx))
+
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				ctx.SetBodyString(`{"ok":false,"error_code":500,"description":"Internal Server Error"}`)
+
+				return
+			}
+
+			ctx.SetBodyString(`{"ok":true,"result":true}`)
+		})
+		defer stop()
+
+		b.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+
+		_, err := b.Upload(MethodSendDocument, nil, UploadPart{
+			Field: "document",
+			File:  NewInputFileBytes("file.txt", []byte(content)),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+
+		atomic.StoreInt32(&attempts, 0)
+
+		_, err = b.Upload(MethodSendDocument, nil, UploadPart{
+			Field: "document",
+			File:  NewInputFileReader("file.txt", io.MultiReader(bytes.NewBufferString(content))),
+		})
+		assert.True(t, xerrors.Is(err, &Error{Code: http.StatusInternalServerError}))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	})
+}
+
+func TestBotUploadParts(t *testing.T) {
+	t.Run("fields", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			form, err := ctx.MultipartForm()
+			if assert.NoError(t, err) {
+				assert.Len(t, form.File["audio"], 1)
+				assert.Len(t, form.File["thumb"], 1)
+				assert.Empty(t, form.Value["audio"])
+			}
+
+			ctx.SetBodyString(`{"ok":true,"result":{"message_id":1}}`)
+		})
+		defer stop()
+
+		p := NewAudio(42, NewInputFileBytes("file.mp3", []byte("audio")))
+		p.Thumb = NewInputFileBytes("file.mp3", []byte("thumb"))
+
+		_, err := b.SendAudio(p)
+		assert.NoError(t, err)
+	})
+	t.Run("sticker", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			assert.Equal(t, "/bot123:abc/sendSticker", string(ctx.Path()))
+
+			form, err := ctx.MultipartForm()
+			if assert.NoError(t, err) {
+				assert.Equal(t, []string{"42"}, form.Value["chat_id"])
+				assert.Empty(t, form.Value["sticker"])
+
+				if assert.Len(t, form.File["sticker"], 1) {
+					assert.Equal(t, "sticker.webp", form.File["sticker"][0].Filename)
+				}
+			}
+
+			assert.Equal(t, "sticker", readUpload(t, ctx))
+			ctx.SetBodyString(`{"ok":true,"result":{"message_id":1}}`)
+		})
+		defer stop()
+
+		_, err := b.SendSticker(NewSticker(42, NewInputFileReader("sticker.webp", strings.NewReader("sticker"))))
+		assert.NoError(t, err)
+	})
+	t.Run("media group", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			form, err := ctx.MultipartForm()
+			if assert.NoError(t, err) {
+				assert.Len(t, form.File["file0"], 1)
+				assert.Len(t, form.File["file1"], 1)
+				assert.JSONEq(t, `[`+
+					`{"type":"photo","media":"attach://file0"},`+
+					`{"type":"photo","media":"file_id"},`+
+					`{"type":"photo","media":"attach://file1"}]`, form.Value["media"][0])
+			}
+
+			ctx.SetBodyString(`{"ok":true,"result":[]}`)
+		})
+		defer stop()
+
+		_, err := b.SendMediaGroup(NewMediaGroup(42,
+			&InputMediaPhoto{Type: TypePhoto, Media: NewInputFileBytes("photo.jpg", []byte("first"))},
+			&InputMediaPhoto{Type: TypePhoto, Media: NewInputFileID("file_id")},
+			&InputMediaPhoto{Type: TypePhoto, Media: NewInputFileBytes("photo.jpg", []byte("second"))},
+		))
+		assert.NoError(t, err)
+	})
+	t.Run("edit media", func(t *testing.T) {
+		b, stop := newTestBot(func(ctx *http.RequestCtx) {
+			form, err := ctx.MultipartForm()
+			if assert.NoError(t, err) {
+				assert.Equal(t, []string{"42"}, form.Value["chat_id"])
+				assert.Equal(t, []string{"1"}, form.Value["message_id"])
+				assert.Len(t, form.File["file0"], 1)
+				assert.Len(t, form.File["file1"], 1)
+				assert.JSONEq(t, `{"type":"document","media":"attach://file0","thumb":"attach://file1"}`,
+					form.Value["media"][0])
+			}
+
+			ctx.SetBodyString(`{"ok":true,"result":{"message_id":1}}`)
+		})
+		defer stop()
+
+		p := NewEditMedia(&InputMediaDocument{
+			Type:  TypeDocument,
+			Media: NewInputFileBytes("file.pdf", []byte("document")),
+			Thumb: NewInputFileBytes("thumb.jpg", []byte("thumb")),
+		})
+		p.ChatID = 42
+		p.MessageID = 1
+
+		_, err := b.EditMessageMedia(p)
+		assert.NoError(t, err)
+	})
+}
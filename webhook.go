@@ -0,0 +1,327 @@
+package telegram
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net"
+	nethttp "net/http"
+	"os"
+	"strings"
+	"sync"
+
+	http "github.com/valyala/fasthttp"
+)
+
+// Webhook receives incoming updates sent by Telegram to the webhook URL. It can be mounted into fasthttp server by
+// HandleFastHTTP method or into net/http server as http.Handler.
+type Webhook struct {
+	// Updates receives incoming updates if Handler is not set.
+	Updates chan *Update
+
+	// Handler handles incoming updates instead of sending them into Updates. If it returns an error, Telegram
+	// receives the 500 response and repeats the request later.
+	Handler Handler
+
+	// ErrorHandler is called for each rejected request. Errors are ignored if it's nil.
+	ErrorHandler func(err error)
+
+	// CheckIP enables rejecting requests from addresses outside of TelegramIPRanges.
+	CheckIP bool
+
+	bot     *Bot
+	path    string
+	stopped chan struct{}
+}
+
+// maxWebhookBodySize limits the size of incoming update body for net/http handler.
+const maxWebhookBodySize = 1 << 20
+
+// TelegramIPRanges contains subnets from which Telegram sends webhook requests.
+var TelegramIPRanges = []*net.IPNet{ //nolint: gochecknoglobals
+	mustParseCIDR("149.154.160.0/20"),
+	mustParseCIDR("91.108.4.0/22"),
+}
+
+// Webhook errors which are passed into ErrorHandler.
+var (
+	ErrWebhookPath   = &Error{Code: http.StatusNotFound, Description: "unknown webhook path"}       //nolint: gochecknoglobals
+	ErrWebhookIP     = &Error{Code: http.StatusForbidden, Description: "forbidden source address"}  //nolint: gochecknoglobals
+	ErrWebhookMethod = &Error{Code: http.StatusMethodNotAllowed, Description: "method not allowed"} //nolint: gochecknoglobals
+)
+
+// NewWebhook creates a new Webhook which accepts updates only by the path. Use NewSecretPath for create a path which
+// cannot be guessed.
+func (b *Bot) NewWebhook(path string) *Webhook {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return &Webhook{
+		Updates: make(chan *Update, 100),
+		bot:     b,
+		path:    path,
+		stopped: make(chan struct{}),
+	}
+}
+
+// NewSecretPath creates a path which contains prefix and random secret segment.
+func NewSecretPath(prefix string) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(prefix, "/") + "/" + hex.EncodeToString(secret), nil
+}
+
+// IsTelegramIP checks that ip is in one of TelegramIPRanges.
+func IsTelegramIP(ip net.IP) bool {
+	for i := range TelegramIPRanges {
+		if TelegramIPRanges[i].Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Path returns the path by which webhook accepts updates.
+func (w *Webhook) Path() string { return w.path }
+
+// HandleFastHTTP is a fasthttp.RequestHandler which accepts incoming updates.
+func (w *Webhook) HandleFastHTTP(ctx *http.RequestCtx) {
+	if err := w.check(string(ctx.Path()), string(ctx.Method()), ctx.RemoteIP()); err != nil {
+		w.reject(err)
+		ctx.Error(err.Description, err.Code)
+
+		return
+	}
+
+	ctx.SetStatusCode(w.handle(ctx, ctx.Request.Body()))
+}
+
+// ServeHTTP implements net/http.Handler which accepts incoming updates.
+func (w *Webhook) ServeHTTP(rw nethttp.ResponseWriter, r *nethttp.Request) {
+	host, _, _ := net.SplitHostPort(r.RemoteAddr)
+	if err := w.check(r.URL.Path, r.Method, net.ParseIP(host)); err != nil {
+		w.reject(err)
+		nethttp.Error(rw, err.Description, err.Code)
+
+		return
+	}
+
+	src, err := ioutil.ReadAll(nethttp.MaxBytesReader(rw, r.Body, maxWebhookBodySize))
+	if err != nil {
+		w.reject(err)
+		rw.WriteHeader(nethttp.StatusBadRequest)
+
+		return
+	}
+
+	rw.WriteHeader(w.handle(r.Context(), src))
+}
+
+// NewWebhookChannel creates channel for receive incoming updates via an outgoing webhook. The fasthttp server accepts
+// updates only by u path (use NewSecretPath for create a secret one) and registers webhook by SetWebhook with u as
+// URL if it's not provided in p.
+//
+// If cert argument is provided by two strings (["path/to/cert.file", "path/to/cert.key"]), then TLS server will be
+// created by this filepaths. Self-signed certificate (see WriteCertificate) is also uploaded by SetWebhook, if p
+// has no other one.
+//
+// The returned function shutdowns the server and returns its error, if any.
+func (b *Bot) NewWebhookChannel(u *http.URI, p SetWebhook, ln net.Listener, crt ...string) (chan *Update,
+	func() error, error) {
+	w := b.NewWebhook(string(u.Path()))
+	srv := &http.Server{
+		Name:              userAgent,
+		Concurrency:       p.MaxConnections,
+		Handler:           w.HandleFastHTTP,
+		ReduceMemoryUsage: true,
+	}
+
+	return b.startWebhook(w, u, p, crt, func() error {
+		if len(crt) == 2 {
+			return srv.ServeTLS(ln, crt[0], crt[1])
+		}
+
+		return srv.Serve(ln)
+	}, func() error {
+		if err := srv.Shutdown(); err != nil {
+			return err
+		}
+
+		// fasthttp ignores Shutdown called before Serve, but Serve stops on closed listener.
+		_ = ln.Close()
+
+		return nil
+	})
+}
+
+// NewWebhookHTTPChannel is the same as NewWebhookChannel, but serves updates by net/http server.
+func (b *Bot) NewWebhookHTTPChannel(u *http.URI, p SetWebhook, ln net.Listener, crt ...string) (chan *Update,
+	func() error, error) {
+	w := b.NewWebhook(string(u.Path()))
+	srv := &nethttp.Server{Handler: w}
+
+	return b.startWebhook(w, u, p, crt, func() (err error) {
+		if len(crt) == 2 {
+			err = srv.ServeTLS(ln, crt[0], crt[1])
+		} else {
+			err = srv.Serve(ln)
+		}
+
+		if err == nethttp.ErrServerClosed {
+			return nil
+		}
+
+		return err
+	}, func() error { return srv.Shutdown(context.Background()) })
+}
+
+// startWebhook starts serving updates for w on the already bound listener in background and registers webhook with
+// self-signed certificate from crt, if any. Returned function stops the server, closes the updates channel and
+// returns the error of serving, if any. Requests which are waiting for the full updates channel are rejected on stop.
+func (b *Bot) startWebhook(w *Webhook, u *http.URI, p SetWebhook, crt []string, serve, stop func() error) (
+	chan *Update, func() error, error) {
+	if p.URL == "" {
+		p.URL = u.String()
+	}
+
+	if len(crt) == 2 {
+		if _, err := tls.LoadX509KeyPair(crt[0], crt[1]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(crt) == 2 && !p.Certificate.IsAttachment() {
+		f, err := openSelfSigned(crt[0])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if f != nil {
+			defer f.Close()
+
+			p.Certificate.Attachment = f
+		}
+	}
+
+	var serveErr error
+
+	served := make(chan struct{})
+
+	go func() {
+		serveErr = serve()
+		close(served)
+	}()
+
+	var (
+		once    sync.Once
+		stopErr error
+	)
+
+	shutdown := func() error {
+		once.Do(func() {
+			close(w.stopped)
+
+			if stopErr = stop(); stopErr != nil {
+				return
+			}
+
+			<-served
+			close(w.Updates)
+
+			stopErr = serveErr
+		})
+
+		return stopErr
+	}
+
+	if _, err := b.SetWebhook(p); err != nil {
+		_ = shutdown()
+
+		return nil, nil, err
+	}
+
+	b.Updates = w.Updates
+
+	return b.Updates, shutdown, nil
+}
+
+// openSelfSigned opens the certificate file if it's self-signed. It returns nil file for other certificates.
+func openSelfSigned(certFile string) (*os.File, error) {
+	src, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := IsSelfSigned(src)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return os.Open(certFile)
+}
+
+// check validates the request path, method and source address.
+func (w *Webhook) check(path, method string, ip net.IP) *Error {
+	switch {
+	case path != w.path:
+		return ErrWebhookPath
+	case w.CheckIP && !IsTelegramIP(ip):
+		return ErrWebhookIP
+	case method != http.MethodPost:
+		return ErrWebhookMethod
+	default:
+		return nil
+	}
+}
+
+// handle decodes update from src and delivers it. It returns the status code of response.
+func (w *Webhook) handle(ctx context.Context, src []byte) int {
+	u := new(Update)
+	if err := w.bot.marshler.Unmarshal(src, u); err != nil {
+		w.reject(err)
+
+		return http.StatusBadRequest
+	}
+
+	if w.Handler != nil {
+		if err := w.Handler.HandleUpdate(ctx, u); err != nil {
+			w.reject(err)
+
+			return http.StatusInternalServerError
+		}
+
+		return http.StatusOK
+	}
+
+	select {
+	case <-ctx.Done():
+		return http.StatusServiceUnavailable
+	case <-w.stopped:
+		return http.StatusServiceUnavailable
+	case w.Updates <- u:
+		return http.StatusOK
+	}
+}
+
+func (w *Webhook) reject(err error) {
+	if w.ErrorHandler != nil {
+		w.ErrorHandler(err)
+	}
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return n
+}
@@ -0,0 +1,236 @@
+package telegram
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	http "github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+	"golang.org/x/xerrors"
+)
+
+func TestNewSecretPath(t *testing.T) {
+	path, err := NewSecretPath("/bot/")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(path, "/bot/"))
+	assert.Len(t, path, len("/bot/")+64)
+
+	other, err := NewSecretPath("/bot")
+	assert.NoError(t, err)
+	assert.NotEqual(t, path, other)
+}
+
+func TestIsTelegramIP(t *testing.T) {
+	for ip, expResult := range map[string]bool{
+		"149.154.160.1":   true,
+		"149.154.175.254": true,
+		"91.108.4.10":     true,
+		"91.108.8.1":      false,
+		"127.0.0.1":       false,
+	} {
+		assert.Equal(t, expResult, IsTelegramIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestWebhookHandleFastHTTP(t *testing.T) {
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {})
+	defer stop()
+
+	w := b.NewWebhook("/secret")
+
+	var rejected error
+	w.ErrorHandler = func(err error) { rejected = err }
+
+	serve := func(method, path, body string) int {
+		ctx := new(http.RequestCtx)
+		ctx.Init(new(http.Request), &net.TCPAddr{IP: net.ParseIP("127.0.0.1")}, nil)
+		ctx.Request.Header.SetMethod(method)
+		ctx.Request.SetRequestURI(path)
+		ctx.Request.SetBodyString(body)
+		w.HandleFastHTTP(ctx)
+
+		return ctx.Response.StatusCode()
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/secret", `{"update_id":1}`))
+		assert.Equal(t, 1, (<-w.Updates).UpdateID)
+	})
+	t.Run("path", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodPost, "/secret/other", `{"update_id":1}`))
+		assert.True(t, xerrors.Is(rejected, ErrWebhookPath))
+	})
+	t.Run("method", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "/secret", ""))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/secret", `{"update_id":`))
+	})
+	t.Run("ip", func(t *testing.T) {
+		w.CheckIP = true
+		defer func() { w.CheckIP = false }()
+
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/secret", `{"update_id":1}`))
+		assert.True(t, xerrors.Is(rejected, ErrWebhookIP))
+	})
+	t.Run("handler", func(t *testing.T) {
+		w.Handler = HandlerFunc(func(context.Context, *Update) error { return xerrors.New("failed") })
+		defer func() { w.Handler = nil }()
+
+		assert.Equal(t, http.StatusInternalServerError, serve(http.MethodPost, "/secret", `{"update_id":1}`))
+	})
+}
+
+func TestWebhookServeHTTP(t *testing.T) {
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {})
+	defer stop()
+
+	w := b.NewWebhook("secret")
+
+	t.Run("ok", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodPost, "/secret", strings.NewReader(`{"update_id":2}`)))
+		assert.Equal(t, nethttp.StatusOK, rec.Code)
+		assert.Equal(t, 2, (<-w.Updates).UpdateID)
+	})
+	t.Run("path", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodPost, "/", strings.NewReader(`{"update_id":2}`)))
+		assert.Equal(t, nethttp.StatusNotFound, rec.Code)
+	})
+	t.Run("ip", func(t *testing.T) {
+		w.CheckIP = true
+		defer func() { w.CheckIP = false }()
+
+		req := httptest.NewRequest(nethttp.MethodPost, "/secret", strings.NewReader(`{"update_id":2}`))
+		req.RemoteAddr = "149.154.167.220:443"
+
+		rec := httptest.NewRecorder()
+		w.ServeHTTP(rec, req)
+		assert.Equal(t, nethttp.StatusOK, rec.Code)
+		assert.Equal(t, 2, (<-w.Updates).UpdateID)
+	})
+}
+
+func TestNewWebhookChannel(t *testing.T) {
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		ctx.SetBodyString(`{"ok":false,"error_code":400,"description":"Bad Request: bad webhook"}`)
+	})
+	defer stop()
+
+	u := http.AcquireURI()
+	defer http.ReleaseURI(u)
+	u.Update("https://example.com/secret")
+
+	t.Run("set webhook", func(t *testing.T) {
+		updates, shutdown, err := b.NewWebhookChannel(u, SetWebhook{}, fasthttputil.NewInmemoryListener())
+		assert.True(t, xerrors.Is(err, ErrBadRequest))
+		assert.Nil(t, updates)
+		assert.Nil(t, shutdown)
+	})
+	t.Run("key", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer os.RemoveAll(dir)
+
+		crt := filepath.Join(dir, "cert.pem")
+		if !assert.NoError(t, WriteCertificate(crt, filepath.Join(dir, "key.pem"), "example.com", time.Hour)) {
+			t.FailNow()
+		}
+
+		updates, shutdown, err := b.NewWebhookChannel(u, SetWebhook{}, fasthttputil.NewInmemoryListener(),
+			crt, filepath.Join(dir, "missing.pem"))
+		assert.Error(t, err)
+		assert.False(t, xerrors.Is(err, ErrBadRequest))
+		assert.Nil(t, updates)
+		assert.Nil(t, shutdown)
+	})
+}
+
+func TestNewWebhookHTTPChannel(t *testing.T) {
+	b, stop := newTestBot(func(ctx *http.RequestCtx) {
+		assert.Equal(t, "/bot123:abc/setWebhook", string(ctx.Path()))
+		ctx.SetBodyString(`{"ok":true,"result":true}`)
+	})
+	defer stop()
+
+	u := http.AcquireURI()
+	defer http.ReleaseURI(u)
+	u.Update("https://example.com/secret")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	updates, shutdown, err := b.NewWebhookHTTPChannel(u, SetWebhook{}, ln)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	resp, err := nethttp.Post("http://"+ln.Addr().String()+"/secret", "application/json",
+		strings.NewReader(`{"update_id":3}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, nethttp.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, 3, (<-updates).UpdateID)
+	assert.NoError(t, shutdown())
+	assert.NoError(t, shutdown())
+
+	_, ok := <-updates
+	assert.False(t, ok)
+}
+
+func TestNewWebhookHTTPChannelServe(t *testing.T) {
+	b, stop := newTestBot(func(ctx *http.RequestCtx) { ctx.SetBodyString(`{"ok":true,"result":true}`) })
+	defer stop()
+
+	u := http.AcquireURI()
+	defer http.ReleaseURI(u)
+	u.Update("https://example.com/secret")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ln.Close()
+
+	updates, shutdown, err := b.NewWebhookHTTPChannel(u, SetWebhook{}, ln)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Error(t, shutdown())
+
+	_, ok := <-updates
+	assert.False(t, ok)
+}
+
+func TestWebhookHandleStopped(t *testing.T) {
+	w := new(Bot).NewWebhook("/secret")
+	w.bot.marshler = json.ConfigFastest
+	w.Updates = make(chan *Update)
+
+	status := make(chan int)
+
+	go func() { status <- w.handle(context.Background(), []byte(`{"update_id":1}`)) }()
+
+	close(w.stopped)
+	assert.Equal(t, http.StatusServiceUnavailable, <-status)
+}